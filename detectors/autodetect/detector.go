@@ -0,0 +1,260 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package autodetect // import "go.opentelemetry.io/contrib/detectors/autodetect"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+const (
+	// otelResourceDetectorsEnvKey is the environment variable name
+	// identifying the resource detectors to use.
+	otelResourceDetectorsEnvKey = "OTEL_RESOURCE_DETECTORS"
+
+	defaultTimeout = 5 * time.Second
+)
+
+type config struct {
+	detectors []resource.Detector
+	timeout   time.Duration
+}
+
+// newConfig returns an appropriately configured config.
+func newConfig(options ...Option) *config {
+	c := &config{timeout: defaultTimeout}
+	for _, option := range options {
+		option.apply(c)
+	}
+
+	return c
+}
+
+// Option applies an autodetect configuration option.
+type Option interface {
+	apply(*config)
+}
+
+type optionFunc func(*config)
+
+func (fn optionFunc) apply(c *config) {
+	fn(c)
+}
+
+// WithDetectors sets the detectors used when the OTEL_RESOURCE_DETECTORS
+// environment variable is not set.
+func WithDetectors(detectors ...resource.Detector) Option {
+	return optionFunc(func(c *config) {
+		c.detectors = append(c.detectors, detectors...)
+	})
+}
+
+// WithTimeout sets the total duration of the detection. The resources of the
+// detectors that did not complete in time are not included in the detected
+// resource. It defaults to 5 seconds, a zero or negative timeout disables it.
+func WithTimeout(timeout time.Duration) Option {
+	return optionFunc(func(c *config) {
+		c.timeout = timeout
+	})
+}
+
+// NewDetector returns a new resource.Detector composed of the detectors
+// defined by the OTEL_RESOURCE_DETECTORS environment variable, a comma
+// separated list of registered detector names. The detectors defined by
+// OTEL_RESOURCE_DETECTORS, if set, take precedence over the ones set with
+// WithDetectors.
+//
+// The supported detector names are by default: host, os, process,
+// container, env, telemetry.sdk, ec2, ecs, eks, lambda, azure.vm,
+// azure.appservice, azure.functions, azure.aks, gcp, k8s, and none. They can
+// be extended with the RegisterDetector function.
+//
+// The detectors are run concurrently, and their resources are merged in the
+// order they are listed: the attributes of a detector take precedence over
+// the ones of the detectors listed before it.
+func NewDetector(opts ...Option) resource.Detector {
+	c := newConfig(opts...)
+
+	// Environment variable defined detectors have precedence over options.
+	names, envDetectors, err := parseEnv()
+	if err != nil {
+		// Communicate to the user their supplied value will not be fully
+		// used.
+		otel.Handle(err)
+	}
+	if envDetectors != nil {
+		return &composite{names: names, detectors: envDetectors, timeout: c.timeout}
+	}
+
+	names = make([]string, len(c.detectors))
+	for i := range c.detectors {
+		names[i] = fmt.Sprintf("detector %d", i)
+	}
+	return &composite{names: names, detectors: c.detectors, timeout: c.timeout}
+}
+
+// errUnknownDetector is returned when an unknown detector name is used.
+var errUnknownDetector = errors.New("unknown resource detector")
+
+// Detector returns a resource.Detector composed from the passed names of
+// registered detectors, run concurrently without timeout. Each name must
+// match an already registered detector (see the RegisterDetector function
+// for more information) or a default.
+//
+// If "none" is included in the arguments, or no names are provided, the
+// returned detector detects an empty resource.
+//
+// An error is returned for any un-registered names. The remaining, known,
+// names will be used to compose a detector that is returned with the error.
+func Detector(names ...string) (resource.Detector, error) {
+	names, detectors, err := lookup(names)
+	if detectors == nil {
+		return nil, err
+	}
+	return &composite{names: names, detectors: detectors}, err
+}
+
+// lookup returns the known names and their registered detectors. A nil
+// slice of detectors is returned if no name is known, and an empty one if
+// "none" is one of the names.
+func lookup(names []string) ([]string, []resource.Detector, error) {
+	var (
+		known   []string
+		found   []resource.Detector
+		unknown []string
+	)
+
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == none {
+			// If "none" is passed in combination with any other detector,
+			// the result still needs to be empty. Therefore, short-circuit
+			// here.
+			return []string{}, []resource.Detector{}, nil
+		}
+
+		d, ok := detectors.load(name)
+		if !ok {
+			unknown = append(unknown, name)
+			continue
+		}
+		known = append(known, name)
+		found = append(found, d)
+	}
+
+	var err error
+	if len(unknown) > 0 {
+		err = fmt.Errorf("%w: %s", errUnknownDetector, strings.Join(unknown, ","))
+	}
+	if len(found) == 0 && len(names) > 0 {
+		return nil, nil, err
+	}
+	if found == nil {
+		found = []resource.Detector{}
+	}
+	return known, found, err
+}
+
+// parseEnv returns the detectors defined by the OTEL_RESOURCE_DETECTORS
+// environment variable. Nil detectors are returned if the environment
+// variable is not set or names no known detector.
+func parseEnv() ([]string, []resource.Detector, error) {
+	v := os.Getenv(otelResourceDetectorsEnvKey)
+	if v == "" {
+		return nil, nil, nil
+	}
+	return lookup(strings.Split(v, ","))
+}
+
+// composite is a resource.Detector running detectors concurrently.
+type composite struct {
+	names     []string
+	detectors []resource.Detector
+	timeout   time.Duration
+}
+
+// detection is the result of a detector.
+type detection struct {
+	index int
+	res   *resource.Resource
+	err   error
+}
+
+// Detect runs the detectors concurrently and merges their resources in
+// order. If the timeout expires or ctx is done before all the detectors
+// complete, or if the resource of a detector has a schema URL conflicting
+// with the ones of the detectors listed before it, the resource of the other
+// detectors is returned with an error wrapping resource.ErrPartialResource.
+func (c *composite) Detect(ctx context.Context) (*resource.Resource, error) {
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	// Buffered so the detectors that complete after the timeout do not
+	// block.
+	results := make(chan detection, len(c.detectors))
+	for i, d := range c.detectors {
+		go func(i int, d resource.Detector) {
+			res, err := d.Detect(ctx)
+			results <- detection{index: i, res: res, err: err}
+		}(i, d)
+	}
+
+	detections := make([]*detection, len(c.detectors))
+wait:
+	for range c.detectors {
+		select {
+		case d := <-results:
+			detections[d.index] = &d
+		case <-ctx.Done():
+			break wait
+		}
+	}
+
+	res := resource.Empty()
+	var errs []error
+	for i, d := range detections {
+		if d == nil {
+			errs = append(errs, fmt.Errorf("%s: %w", c.names[i], ctx.Err()))
+			continue
+		}
+		if d.err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", c.names[i], d.err))
+			if !errors.Is(d.err, resource.ErrPartialResource) {
+				continue
+			}
+		}
+		merged, err := resource.Merge(res, d.res)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", c.names[i], err))
+			continue
+		}
+		res = merged
+	}
+
+	if len(errs) > 0 {
+		return res, fmt.Errorf("%w: %w", resource.ErrPartialResource, errors.Join(errs...))
+	}
+	return res, nil
+}
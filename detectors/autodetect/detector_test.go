@@ -0,0 +1,171 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package autodetect
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+)
+
+// fakeDetector detects a resource after a delay, or fails.
+type fakeDetector struct {
+	delay     time.Duration
+	schemaURL string
+	attrs     []attribute.KeyValue
+	err       error
+}
+
+func (d fakeDetector) Detect(ctx context.Context) (*resource.Resource, error) {
+	select {
+	case <-time.After(d.delay):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if d.err != nil {
+		return nil, d.err
+	}
+	return resource.NewWithAttributes(d.schemaURL, d.attrs...), nil
+}
+
+func register(t *testing.T, name string, d resource.Detector) {
+	RegisterDetector(name, d)
+	t.Cleanup(func() { detectors.drop(name) })
+}
+
+func TestNewDetectorFromEnv(t *testing.T) {
+	// The slower detector is listed last and takes precedence.
+	register(t, "first", fakeDetector{
+		delay: 20 * time.Millisecond,
+		attrs: []attribute.KeyValue{attribute.String("a", "first"), attribute.String("b", "first")},
+	})
+	register(t, "second", fakeDetector{
+		attrs: []attribute.KeyValue{attribute.String("a", "second")},
+	})
+	register(t, "third", fakeDetector{
+		delay: 40 * time.Millisecond,
+		attrs: []attribute.KeyValue{attribute.String("b", "third")},
+	})
+	t.Setenv(otelResourceDetectorsEnvKey, "first, second,third")
+
+	r, err := NewDetector(WithDetectors(noop)).Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, resource.NewSchemaless(
+		attribute.String("a", "second"),
+		attribute.String("b", "third"),
+	), r)
+}
+
+func TestNewDetectorSchemaURLs(t *testing.T) {
+	register(t, "current", fakeDetector{
+		schemaURL: semconv.SchemaURL,
+		attrs:     []attribute.KeyValue{semconv.HostName("host")},
+	})
+	register(t, "older", fakeDetector{
+		schemaURL: "https://opentelemetry.io/schemas/1.17.0",
+		attrs:     []attribute.KeyValue{semconv.CloudProviderGCP},
+	})
+	register(t, "schemaless", fakeDetector{
+		attrs: []attribute.KeyValue{attribute.String("a", "b")},
+	})
+	t.Setenv(otelResourceDetectorsEnvKey, "current,older,schemaless")
+
+	r, err := NewDetector().Detect(context.Background())
+	assert.ErrorIs(t, err, resource.ErrPartialResource)
+	assert.ErrorContains(t, err, "older: cannot merge resource due to conflicting Schema URL")
+	assert.Equal(t, resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.HostName("host"),
+		attribute.String("a", "b"),
+	), r)
+}
+
+func TestNewDetectorWithDetectors(t *testing.T) {
+	t.Setenv(otelResourceDetectorsEnvKey, "")
+
+	r, err := NewDetector(WithDetectors(noop)).Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, resource.NewSchemaless(attribute.String("noop", "noop")), r)
+
+	r, err = NewDetector().Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, resource.Empty(), r)
+}
+
+func TestNewDetectorNone(t *testing.T) {
+	register(t, "custom", noop)
+	t.Setenv(otelResourceDetectorsEnvKey, "custom,none")
+
+	r, err := NewDetector(WithDetectors(noop)).Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, resource.Empty(), r)
+}
+
+func TestNewDetectorTimeout(t *testing.T) {
+	register(t, "fast", fakeDetector{attrs: []attribute.KeyValue{attribute.String("fast", "true")}})
+	register(t, "slow", fakeDetector{delay: time.Minute, attrs: []attribute.KeyValue{attribute.String("slow", "true")}})
+	t.Setenv(otelResourceDetectorsEnvKey, "fast,slow")
+
+	r, err := NewDetector(WithTimeout(10 * time.Millisecond)).Detect(context.Background())
+	assert.ErrorIs(t, err, resource.ErrPartialResource)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.ErrorContains(t, err, "slow: ")
+	assert.Equal(t, resource.NewSchemaless(attribute.String("fast", "true")), r)
+}
+
+func TestDetectorErrors(t *testing.T) {
+	errDetect := errors.New("detection failed")
+	register(t, "ok", noop)
+	register(t, "failing", fakeDetector{err: errDetect})
+
+	d, err := Detector("ok", "unknown", "failing")
+	assert.ErrorIs(t, err, errUnknownDetector)
+	assert.EqualError(t, err, "unknown resource detector: unknown")
+
+	r, err := d.Detect(context.Background())
+	assert.ErrorIs(t, err, resource.ErrPartialResource)
+	assert.ErrorIs(t, err, errDetect)
+	assert.ErrorContains(t, err, "failing: detection failed")
+	assert.Equal(t, resource.NewSchemaless(attribute.String("noop", "noop")), r)
+}
+
+func TestDetectorUnknownOnly(t *testing.T) {
+	d, err := Detector("unknown")
+	assert.ErrorIs(t, err, errUnknownDetector)
+	assert.Nil(t, d)
+
+	d, err = Detector()
+	require.NoError(t, err)
+	r, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, resource.Empty(), r)
+}
+
+func TestDetectorSDK(t *testing.T) {
+	t.Setenv("OTEL_RESOURCE_ATTRIBUTES", "key=value")
+
+	d, err := Detector("env")
+	require.NoError(t, err)
+	r, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, resource.NewSchemaless(attribute.String("key", "value")), r)
+}
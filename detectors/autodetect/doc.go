@@ -0,0 +1,23 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package autodetect provides an OpenTelemetry resource.Detector composed of
+// the resource detectors named by the OTEL_RESOURCE_DETECTORS environment
+// variable, so the detectors of a service can be selected when it is
+// deployed instead of being hard-coded.
+//
+// The detectors of this repository, and the ones of the OpenTelemetry SDK,
+// are registered by default. Custom detectors are registered with the
+// RegisterDetector function.
+package autodetect // import "go.opentelemetry.io/contrib/detectors/autodetect"
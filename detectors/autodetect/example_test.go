@@ -0,0 +1,50 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package autodetect_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/contrib/detectors/autodetect"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+func ExampleNewDetector() {
+	// The detectors set for the OTEL_RESOURCE_DETECTORS environment variable
+	// take precedence over the ones passed with WithDetectors.
+	_ = os.Setenv("OTEL_RESOURCE_DETECTORS", "env")
+	_ = os.Setenv("OTEL_RESOURCE_ATTRIBUTES", "deployment.environment=production")
+	defer func() {
+		_ = os.Unsetenv("OTEL_RESOURCE_DETECTORS")
+		_ = os.Unsetenv("OTEL_RESOURCE_ATTRIBUTES")
+	}()
+
+	res, err := resource.New(context.Background(),
+		resource.WithDetectors(autodetect.NewDetector(
+			autodetect.WithDetectors(resource.StringDetector("", "fallback", func() (string, error) {
+				return "true", nil
+			})),
+		)),
+	)
+	if err != nil {
+		panic(err)
+	}
+	v, _ := res.Set().Value(attribute.Key("deployment.environment"))
+	fmt.Println(v.AsString())
+	// Output: production
+}
@@ -0,0 +1,159 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package autodetect // import "go.opentelemetry.io/contrib/detectors/autodetect"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"go.opentelemetry.io/contrib/detectors/aws/ec2"
+	"go.opentelemetry.io/contrib/detectors/aws/ecs"
+	"go.opentelemetry.io/contrib/detectors/aws/eks"
+	"go.opentelemetry.io/contrib/detectors/aws/lambda"
+	"go.opentelemetry.io/contrib/detectors/azure"
+	"go.opentelemetry.io/contrib/detectors/gcp"
+	"go.opentelemetry.io/contrib/detectors/k8s"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// none is the special "detector" name that means no detector shall be
+// configured.
+const none = "none"
+
+// detectors is the registry of resource detectors registered with this
+// package. It includes the detectors of this repository and of the
+// OpenTelemetry SDK at startup.
+var detectors = &registry{
+	names: map[string]resource.Detector{
+		// OpenTelemetry SDK.
+		"host":          sdkDetector(resource.WithHost()),
+		"os":            sdkDetector(resource.WithOS()),
+		"process":       sdkDetector(resource.WithProcess()),
+		"container":     sdkDetector(resource.WithContainer()),
+		"env":           sdkDetector(resource.WithFromEnv()),
+		"telemetry.sdk": sdkDetector(resource.WithTelemetrySDK()),
+
+		// Amazon Web Services.
+		"ec2":    newLazyDetector(func() resource.Detector { return ec2.NewResourceDetector() }),
+		"ecs":    newLazyDetector(ecs.NewResourceDetector),
+		"eks":    newLazyDetector(func() resource.Detector { return eks.NewResourceDetector() }),
+		"lambda": newLazyDetector(func() resource.Detector { return lambda.NewResourceDetector() }),
+
+		// Microsoft Azure.
+		"azure.vm":         newLazyDetector(func() resource.Detector { return azure.NewVMResourceDetector() }),
+		"azure.appservice": newLazyDetector(azure.NewAppServiceResourceDetector),
+		"azure.functions":  newLazyDetector(azure.NewFunctionsResourceDetector),
+		"azure.aks":        newLazyDetector(func() resource.Detector { return azure.NewAKSResourceDetector() }),
+
+		// Google Cloud Platform.
+		"gcp": newLazyDetector(gcp.NewDetector),
+
+		// Kubernetes.
+		"k8s": newLazyDetector(k8s.NewResourceDetector),
+	},
+}
+
+// registry maintains a map of detector names to resource.Detector
+// implementations that is safe for concurrent use by multiple goroutines
+// without additional locking or coordination.
+type registry struct {
+	mu    sync.Mutex
+	names map[string]resource.Detector
+}
+
+// load returns the value stored in the registry index for a key, or nil if no
+// value is present. The ok result indicates whether value was found in the
+// index.
+func (r *registry) load(key string) (d resource.Detector, ok bool) {
+	r.mu.Lock()
+	d, ok = r.names[key]
+	r.mu.Unlock()
+	return d, ok
+}
+
+var errDupReg = errors.New("duplicate registration")
+
+// store sets the value for a key if is not already in the registry. errDupReg
+// is returned if the registry already contains key.
+func (r *registry) store(key string, value resource.Detector) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.names == nil {
+		r.names = map[string]resource.Detector{key: value}
+		return nil
+	}
+	if _, ok := r.names[key]; ok {
+		return fmt.Errorf("%w: %q", errDupReg, key)
+	}
+	r.names[key] = value
+	return nil
+}
+
+// drop removes key from the registry if it exists, otherwise nothing.
+func (r *registry) drop(key string) {
+	r.mu.Lock()
+	delete(r.names, key)
+	r.mu.Unlock()
+}
+
+// RegisterDetector sets the resource.Detector d to be used when the
+// OTEL_RESOURCE_DETECTORS environment variable contains the detector name.
+// This will panic if name has already been registered or is a default (host,
+// os, process, container, env, telemetry.sdk, ec2, ecs, eks, lambda,
+// azure.vm, azure.appservice, azure.functions, azure.aks, gcp, or k8s).
+func RegisterDetector(name string, d resource.Detector) {
+	if err := detectors.store(name, d); err != nil {
+		// Panic so the user is made aware of the duplicate registration,
+		// which could be done by malicious code trying to alter the
+		// identity of the telemetry.
+		panic(err)
+	}
+}
+
+// lazyDetector is a resource.Detector created on its first detection, so
+// the detectors that are not used are never created.
+type lazyDetector struct {
+	once     sync.Once
+	new      func() resource.Detector
+	detector resource.Detector
+}
+
+func newLazyDetector(newDetector func() resource.Detector) *lazyDetector {
+	return &lazyDetector{new: newDetector}
+}
+
+// Detect creates the detector if needed and returns its detected resource.
+func (d *lazyDetector) Detect(ctx context.Context) (*resource.Resource, error) {
+	d.once.Do(func() { d.detector = d.new() })
+	return d.detector.Detect(ctx)
+}
+
+// detectorFunc is a resource.Detector implemented by a function.
+type detectorFunc func(context.Context) (*resource.Resource, error)
+
+// Detect returns the resource detected by fn.
+func (fn detectorFunc) Detect(ctx context.Context) (*resource.Resource, error) {
+	return fn(ctx)
+}
+
+// sdkDetector returns a resource.Detector detecting the resource of an
+// OpenTelemetry SDK option, as the SDK does not export its detectors.
+func sdkDetector(opt resource.Option) resource.Detector {
+	return detectorFunc(func(ctx context.Context) (*resource.Resource, error) {
+		return resource.New(ctx, opt)
+	})
+}
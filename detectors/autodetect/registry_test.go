@@ -0,0 +1,126 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package autodetect
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+var noop = fakeDetector{attrs: []attribute.KeyValue{attribute.String("noop", "noop")}}
+
+func TestRegistryEmptyStore(t *testing.T) {
+	r := registry{}
+	assert.NotPanics(t, func() {
+		require.NoError(t, r.store("first", noop))
+	})
+}
+
+func TestRegistryEmptyLoad(t *testing.T) {
+	r := registry{}
+	assert.NotPanics(t, func() {
+		v, ok := r.load("non-existent")
+		assert.False(t, ok, "empty registry should hold nothing")
+		assert.Nil(t, v, "non-nil detector returned")
+	})
+}
+
+func TestRegistryConcurrentSafe(t *testing.T) {
+	const detectorName = "detector"
+
+	r := registry{}
+	assert.NotPanics(t, func() {
+		require.NoError(t, r.store(detectorName, noop))
+	})
+
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		assert.NotPanics(t, func() {
+			require.ErrorIs(t, r.store(detectorName, noop), errDupReg)
+		})
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		assert.NotPanics(t, func() {
+			v, ok := r.load(detectorName)
+			assert.True(t, ok, "missing detector in registry")
+			assert.Equal(t, noop, v, "wrong detector retuned")
+		})
+	}()
+
+	wg.Wait()
+}
+
+func TestRegisterDetector(t *testing.T) {
+	const detectorName = "custom"
+	RegisterDetector(detectorName, noop)
+	t.Cleanup(func() { detectors.drop(detectorName) })
+
+	v, ok := detectors.load(detectorName)
+	assert.True(t, ok, "missing detector in registry")
+	assert.Equal(t, noop, v, "wrong detector stored")
+}
+
+func TestDuplicateRegisterDetectorPanics(t *testing.T) {
+	const detectorName = "custom"
+	RegisterDetector(detectorName, noop)
+	t.Cleanup(func() { detectors.drop(detectorName) })
+
+	errString := fmt.Sprintf("%s: %q", errDupReg, detectorName)
+	assert.PanicsWithError(t, errString, func() {
+		RegisterDetector(detectorName, noop)
+	})
+}
+
+func TestDefaultDetectorsRegistered(t *testing.T) {
+	for _, name := range []string{
+		"host", "os", "process", "container", "env", "telemetry.sdk",
+		"ec2", "ecs", "eks", "lambda",
+		"azure.vm", "azure.appservice", "azure.functions", "azure.aks",
+		"gcp", "k8s",
+	} {
+		_, ok := detectors.load(name)
+		assert.True(t, ok, name)
+	}
+}
+
+func TestLazyDetector(t *testing.T) {
+	var created int
+	d := newLazyDetector(func() resource.Detector {
+		created++
+		return noop
+	})
+	assert.Equal(t, 0, created, "detector created before detection")
+
+	for i := 0; i < 2; i++ {
+		r, err := d.Detect(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, resource.NewSchemaless(attribute.String("noop", "noop")), r)
+	}
+	assert.Equal(t, 1, created)
+}
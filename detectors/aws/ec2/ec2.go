@@ -18,7 +18,10 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strings"
+	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/ec2metadata"
 	"github.com/aws/aws-sdk-go/aws/session"
@@ -28,8 +31,33 @@ import (
 	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
 )
 
+const (
+	// tagsCategory is the metadata category listing the instance tags, when
+	// access to the tags is allowed in the instance metadata options.
+	tagsCategory = "tags/instance"
+	// autoScalingGroupTag is the tag EC2 Auto Scaling sets on its instances.
+	autoScalingGroupTag = "aws:autoscaling:groupName"
+	// defaultTimeout and defaultMaxRetries are the timeout and retries of
+	// the ec2metadata client with the default HTTP client.
+	defaultTimeout    = time.Second
+	defaultMaxRetries = 2
+)
+
+// Resource attributes of EC2 that are not defined by the semantic
+// conventions.
+const (
+	tagKeyPrefix            = "ec2.tag."
+	autoScalingGroupNameKey = attribute.Key("aws.autoscaling.group.name")
+)
+
 type config struct {
-	c Client
+	c                    Client
+	timeout              time.Duration
+	maxRetries           *int
+	disableIMDSv1        bool
+	tags                 bool
+	tagKeys              []string
+	autoScalingGroupName bool
 }
 
 // newConfig returns an appropriately configured config.
@@ -60,13 +88,84 @@ func WithClient(t Client) Option {
 	})
 }
 
+// WithTimeout sets the timeout of the requests to the instance metadata
+// service (IMDS) made by the default client. It defaults to one second.
+func WithTimeout(timeout time.Duration) Option {
+	return optionFunc(func(c *config) {
+		c.timeout = timeout
+	})
+}
+
+// WithMaxRetries sets the maximum number of retries of the requests to the
+// IMDS made by the default client. It defaults to 2.
+func WithMaxRetries(maxRetries int) Option {
+	return optionFunc(func(c *config) {
+		c.maxRetries = &maxRetries
+	})
+}
+
+// WithIMDSv1FallbackDisabled disables the fallback of the default client to
+// IMDSv1 when no IMDSv2 session token can be retrieved, e.g. because the
+// hop limit of the token response is too low in a container.
+func WithIMDSv1FallbackDisabled() Option {
+	return optionFunc(func(c *config) {
+		c.disableIMDSv1 = true
+	})
+}
+
+// WithInstanceTags enables the detection of the instance tags with the
+// given keys as ec2.tag.<key> attributes. All tags are detected if no key is
+// given. It requires the access to the tags in the instance metadata to be
+// allowed.
+func WithInstanceTags(keys ...string) Option {
+	return optionFunc(func(c *config) {
+		c.tags = true
+		c.tagKeys = append(c.tagKeys, keys...)
+	})
+}
+
+// WithAutoScalingGroupName enables the detection of the name of the Auto
+// Scaling group of the instance as the aws.autoscaling.group.name attribute,
+// from its aws:autoscaling:groupName tag. It requires the access to the tags
+// in the instance metadata to be allowed.
+func WithAutoScalingGroupName() Option {
+	return optionFunc(func(c *config) {
+		c.autoScalingGroupName = true
+	})
+}
+
 func (cfg *config) getClient() Client {
 	return cfg.c
 }
 
+// awsConfig returns the configuration of the default client.
+func (cfg *config) awsConfig() *aws.Config {
+	c := aws.NewConfig()
+	if cfg.timeout > 0 || cfg.maxRetries != nil {
+		// The ec2metadata client only sets its own timeout and retries with
+		// the default HTTP client, both are set when one is configured.
+		timeout, maxRetries := defaultTimeout, defaultMaxRetries
+		if cfg.timeout > 0 {
+			timeout = cfg.timeout
+		}
+		if cfg.maxRetries != nil {
+			maxRetries = *cfg.maxRetries
+		}
+		c = c.WithHTTPClient(&http.Client{Timeout: timeout}).WithMaxRetries(maxRetries)
+	}
+	if cfg.disableIMDSv1 {
+		c = c.WithEC2MetadataEnableFallback(false)
+	}
+	return c
+}
+
 // resource detector collects resource information from EC2 environment.
 type resourceDetector struct {
-	c Client
+	c                    Client
+	awsConfig            *aws.Config
+	tags                 bool
+	tagKeys              []string
+	autoScalingGroupName bool
 }
 
 // Client implements methods to capture EC2 environment metadata information.
@@ -82,7 +181,13 @@ var _ resource.Detector = (*resourceDetector)(nil)
 // NewResourceDetector returns a resource detector that will detect AWS EC2 resources.
 func NewResourceDetector(opts ...Option) resource.Detector {
 	c := newConfig(opts...)
-	return &resourceDetector{c.getClient()}
+	return &resourceDetector{
+		c:                    c.getClient(),
+		awsConfig:            c.awsConfig(),
+		tags:                 c.tags,
+		tagKeys:              c.tagKeys,
+		autoScalingGroupName: c.autoScalingGroupName,
+	}
 }
 
 // Detect detects associated resources when running in AWS environment.
@@ -111,9 +216,18 @@ func (detector *resourceDetector) Detect(ctx context.Context) (*resource.Resourc
 		semconv.HostImageID(doc.ImageID),
 		semconv.HostType(doc.InstanceType),
 	}
+	if arch := hostArch(doc.Architecture); arch.Valid() {
+		attributes = append(attributes, arch)
+	}
 
 	m := &metadata{client: client}
 	m.add(semconv.HostNameKey, "hostname")
+	if detector.autoScalingGroupName {
+		m.add(autoScalingGroupNameKey, tagsCategory+"/"+autoScalingGroupTag)
+	}
+	if detector.tags {
+		m.addTags(detector.tagKeys)
+	}
 
 	attributes = append(attributes, m.attributes...)
 
@@ -134,7 +248,24 @@ func (detector *resourceDetector) client() (Client, error) {
 		return nil, err
 	}
 
-	return ec2metadata.New(s), nil
+	return ec2metadata.New(s, detector.awsConfig), nil
+}
+
+// hostArch returns the host.arch attribute of the architecture of the
+// instance identity document.
+func hostArch(arch string) attribute.KeyValue {
+	switch arch {
+	case "":
+		return attribute.KeyValue{}
+	case "x86_64":
+		return semconv.HostArchAMD64
+	case "arm64":
+		return semconv.HostArchARM64
+	case "i386":
+		return semconv.HostArchX86
+	default:
+		return semconv.HostArchKey.String(arch)
+	}
 }
 
 type metadata struct {
@@ -144,21 +275,44 @@ type metadata struct {
 }
 
 func (m *metadata) add(k attribute.Key, n string) {
+	if v, ok := m.get(n); ok {
+		m.attributes = append(m.attributes, k.String(v))
+	}
+}
+
+// addTags adds the instance tags with keys, or all the instance tags if keys
+// is empty.
+func (m *metadata) addTags(keys []string) {
+	if len(keys) == 0 {
+		v, ok := m.get(tagsCategory)
+		if !ok {
+			return
+		}
+		keys = strings.Fields(v)
+	}
+	for _, key := range keys {
+		m.add(attribute.Key(tagKeyPrefix+key), tagsCategory+"/"+key)
+	}
+}
+
+// get returns the metadata at path n, and if it is found. The errors other
+// than a not found response are recorded.
+func (m *metadata) get(n string) (string, bool) {
 	v, err := m.client.GetMetadata(n)
 	if err == nil {
-		m.attributes = append(m.attributes, k.String(v))
-		return
+		return v, true
 	}
 
 	rf, ok := err.(awserr.RequestFailure)
 	if !ok {
 		m.errs = append(m.errs, fmt.Errorf("%q: %w", n, err))
-		return
+		return "", false
 	}
 
 	if rf.StatusCode() == http.StatusNotFound {
-		return
+		return "", false
 	}
 
 	m.errs = append(m.errs, fmt.Errorf("%q: %d %s", n, rf.StatusCode(), rf.Code()))
+	return "", false
 }
@@ -70,6 +70,7 @@ func TestAWS_Detect(t *testing.T) {
 		semconv.HostID("i-1234567890abcdef0"),
 		semconv.HostImageID("ami-5fb8c835"),
 		semconv.HostType("t2.micro"),
+		semconv.HostArchAMD64,
 	}
 
 	testTable := map[string]struct {
@@ -146,6 +147,7 @@ func TestAWS_Detect(t *testing.T) {
 				semconv.HostImageID("ami-5fb8c835"),
 				semconv.HostName("ip-12-34-56-78.us-west-2.compute.internal"),
 				semconv.HostType("t2.micro"),
+				semconv.HostArchAMD64,
 			)},
 		},
 	}
@@ -173,6 +175,90 @@ func TestAWS_Detect(t *testing.T) {
 	}
 }
 
+func TestAWS_DetectTags(t *testing.T) {
+	doc := func() (ec2metadata.EC2InstanceIdentityDocument, error) {
+		return ec2metadata.EC2InstanceIdentityDocument{
+			Region:       "us-west-2",
+			InstanceID:   "i-1234567890abcdef0",
+			Architecture: "arm64",
+		}, nil
+	}
+	client := &clientMock{
+		available: true,
+		idDoc:     doc,
+		metadata: map[string]meta{
+			"tags/instance":                           {value: "Name\nteam\naws:autoscaling:groupName"},
+			"tags/instance/Name":                      {value: "web-1"},
+			"tags/instance/team":                      {value: "platform"},
+			"tags/instance/aws:autoscaling:groupName": {value: "web-asg"},
+		},
+	}
+	base := []attribute.KeyValue{
+		semconv.CloudProviderAWS,
+		semconv.CloudPlatformAWSEC2,
+		semconv.CloudRegion("us-west-2"),
+		semconv.CloudAvailabilityZone(""),
+		semconv.CloudAccountID(""),
+		semconv.HostID("i-1234567890abcdef0"),
+		semconv.HostImageID(""),
+		semconv.HostType(""),
+		semconv.HostArchARM64,
+	}
+
+	tests := map[string]struct {
+		opts []Option
+		want []attribute.KeyValue
+	}{
+		"Selected Tags": {
+			opts: []Option{WithInstanceTags("team", "missing")},
+			want: []attribute.KeyValue{attribute.String("ec2.tag.team", "platform")},
+		},
+		"All Tags": {
+			opts: []Option{WithInstanceTags()},
+			want: []attribute.KeyValue{
+				attribute.String("ec2.tag.Name", "web-1"),
+				attribute.String("ec2.tag.team", "platform"),
+				attribute.String("ec2.tag.aws:autoscaling:groupName", "web-asg"),
+			},
+		},
+		"Auto Scaling Group": {
+			opts: []Option{WithAutoScalingGroupName()},
+			want: []attribute.KeyValue{autoScalingGroupNameKey.String("web-asg")},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			r, err := NewResourceDetector(append(tt.opts, WithClient(client))...).Detect(context.Background())
+			require.NoError(t, err)
+			want := resource.NewWithAttributes(semconv.SchemaURL, append(base, tt.want...)...)
+			assert.Equal(t, want, r)
+		})
+	}
+
+	t.Run("Tags Not Allowed", func(t *testing.T) {
+		client := &clientMock{available: true, idDoc: doc}
+		r, err := NewResourceDetector(WithClient(client), WithInstanceTags(), WithAutoScalingGroupName()).Detect(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, resource.NewWithAttributes(semconv.SchemaURL, base...), r)
+	})
+}
+
+func TestAWSConfig(t *testing.T) {
+	c := newConfig().awsConfig()
+	assert.Nil(t, c.HTTPClient)
+	assert.Nil(t, c.MaxRetries)
+	assert.Nil(t, c.EC2MetadataEnableFallback)
+
+	c = newConfig(WithTimeout(100 * time.Millisecond)).awsConfig()
+	assert.Equal(t, 100*time.Millisecond, c.HTTPClient.Timeout)
+	assert.Equal(t, defaultMaxRetries, *c.MaxRetries)
+
+	c = newConfig(WithMaxRetries(0), WithIMDSv1FallbackDisabled()).awsConfig()
+	assert.Equal(t, defaultTimeout, c.HTTPClient.Timeout)
+	assert.Equal(t, 0, *c.MaxRetries)
+	assert.False(t, *c.EC2MetadataEnableFallback)
+}
+
 type clientMock struct {
 	available bool
 	idDoc     func() (ec2metadata.EC2InstanceIdentityDocument, error)
@@ -25,6 +25,7 @@ import (
 
 	ecsmetadata "github.com/brunoscheufler/aws-ecs-metadata-go"
 
+	"go.opentelemetry.io/contrib/detectors/aws/ecs/internal/containerutil"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/sdk/resource"
 	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
@@ -32,11 +33,9 @@ import (
 
 const (
 	// TypeStr is AWS ECS type.
-	TypeStr           = "ecs"
-	metadataV3EnvVar  = "ECS_CONTAINER_METADATA_URI"
-	metadataV4EnvVar  = "ECS_CONTAINER_METADATA_URI_V4"
-	containerIDLength = 64
-	defaultCgroupPath = "/proc/self/cgroup"
+	TypeStr          = "ecs"
+	metadataV3EnvVar = "ECS_CONTAINER_METADATA_URI"
+	metadataV4EnvVar = "ECS_CONTAINER_METADATA_URI_V4"
 )
 
 var (
@@ -216,19 +215,10 @@ func (ecsUtils ecsDetectorUtils) getContainerID() (string, error) {
 		return "", nil
 	}
 
-	fileData, err := os.ReadFile(defaultCgroupPath)
-	if err != nil {
-		// Cgroups file not found.
-		// For example, windows; or when running integration tests outside of a container.
-		return "", nil
-	}
-	splitData := strings.Split(strings.TrimSpace(string(fileData)), "\n")
-	for _, str := range splitData {
-		if len(str) > containerIDLength {
-			return str[len(str)-containerIDLength:], nil
-		}
-	}
-	return "", nil
+	// The files are not found e.g. when running integration tests outside of
+	// a container, and with cgroup v2 the container ID is only found in the
+	// mounts of Docker containers.
+	return containerutil.ContainerID(containerutil.DefaultCgroupPath, containerutil.DefaultMountinfoPath), nil
 }
 
 // returns host name reported by the kernel.
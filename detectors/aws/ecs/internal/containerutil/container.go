@@ -0,0 +1,84 @@
+// Code created by gotmpl. DO NOT MODIFY.
+// source: internal/shared/containerutil/container.go.tmpl
+
+// Copyright The OpenTelemetry Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package containerutil
+
+import (
+	"os"
+	"regexp"
+	"strings"
+)
+
+const (
+	// DefaultCgroupPath is the path of the cgroup file of the process.
+	DefaultCgroupPath = "/proc/self/cgroup"
+	// DefaultMountinfoPath is the path of the mountinfo file of the process.
+	DefaultMountinfoPath = "/proc/self/mountinfo"
+)
+
+var (
+	// cgroupContainerID matches the container ID at the end of a cgroup v1
+	// path, as written by docker, containerd and CRI-O, e.g.
+	// "/kubepods/besteffort/pod<uid>/<id>" or ".../cri-containerd-<id>.scope".
+	cgroupContainerID = regexp.MustCompile(`([0-9a-f]{64})(?:\.scope)?$`)
+	// mountinfoContainerID matches the container ID in the source of the
+	// /etc/hostname mount of a Docker container, the only place it is found
+	// when the cgroup v2 namespace of the container hides the cgroup path.
+	// containerd and CRI-O mount the hostname file of the pod sandbox
+	// instead, whose ID is not the one of the container, so it is not
+	// matched.
+	mountinfoContainerID = regexp.MustCompile(`/containers/([0-9a-f]{64})/hostname`)
+)
+
+// ContainerID returns the ID of the container the process runs in, read
+// from the cgroup file at cgroupPath or, with cgroup v2 where that file only
+// holds "0::/", from the mountinfo file at mountinfoPath. An empty string is
+// returned if no container ID is found.
+func ContainerID(cgroupPath, mountinfoPath string) string {
+	if data, err := os.ReadFile(cgroupPath); err == nil {
+		if id := ContainerIDFromCgroup(string(data)); id != "" {
+			return id
+		}
+	}
+	if data, err := os.ReadFile(mountinfoPath); err == nil {
+		return ContainerIDFromMountinfo(string(data))
+	}
+	return ""
+}
+
+// ContainerIDFromCgroup returns the container ID found in the content of a
+// cgroup v1 /proc/self/cgroup file, or an empty string.
+func ContainerIDFromCgroup(data string) string {
+	for _, line := range strings.Split(strings.TrimSpace(data), "\n") {
+		if m := cgroupContainerID.FindStringSubmatch(strings.TrimSpace(line)); m != nil {
+			return m[1]
+		}
+	}
+	return ""
+}
+
+// ContainerIDFromMountinfo returns the container ID found in the content of
+// a /proc/self/mountinfo file, or an empty string. Only Docker exposes the
+// container ID in the mounts of the container: an empty string is returned
+// for containers run by containerd or CRI-O with cgroup v2.
+func ContainerIDFromMountinfo(data string) string {
+	for _, line := range strings.Split(strings.TrimSpace(data), "\n") {
+		if m := mountinfoContainerID.FindStringSubmatch(line); m != nil {
+			return m[1]
+		}
+	}
+	return ""
+}
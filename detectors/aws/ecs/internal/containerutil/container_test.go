@@ -0,0 +1,80 @@
+// Code created by gotmpl. DO NOT MODIFY.
+// source: internal/shared/containerutil/container_test.go.tmpl
+
+// Copyright The OpenTelemetry Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package containerutil
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const containerID = "1f5b0a6f3e2d4c7b8a9e0d1c2b3a4f5e6d7c8b9a0f1e2d3c4b5a69788796a5b4"
+
+func TestContainerIDFromCgroup(t *testing.T) {
+	for _, data := range []string{
+		"12:pids:/kubepods/besteffort/pod0b3b3b6c-1d6e-4b8a-9d49-1e0f9e3c2a41/" + containerID,
+		"0::/kubepods.slice/kubepods-besteffort.slice/kubepods-besteffort-pod0b3b3b6c_1d6e_4b8a_9d49_1e0f9e3c2a41.slice/cri-containerd-" + containerID + ".scope",
+		"9:perf_event:/ecs/task-id/" + containerID,
+		"12:pids:/docker/" + containerID + "\n1:name=systemd:/docker/" + containerID + "\n",
+	} {
+		assert.Equal(t, containerID, ContainerIDFromCgroup(data), data)
+	}
+	assert.Equal(t, "", ContainerIDFromCgroup("0::/"))
+}
+
+func TestContainerIDFromMountinfo(t *testing.T) {
+	data := "736 717 0:61 / / rw,relatime - overlay overlay rw\n" +
+		"749 736 259:1 /var/lib/docker/containers/" + containerID + "/hostname /etc/hostname rw,relatime - ext4 /dev/root rw\n"
+	assert.Equal(t, containerID, ContainerIDFromMountinfo(data))
+	assert.Equal(t, "", ContainerIDFromMountinfo("736 717 0:61 / / rw,relatime - overlay overlay rw"))
+}
+
+func TestContainerIDFromMountinfoSandbox(t *testing.T) {
+	// containerd and CRI-O mount the files of the pod sandbox, whose ID must
+	// not be reported as the one of the container.
+	const sandboxID = "9d0a3c5e7f1b2d4c6e8a0b1c3d5e7f9a1b3c5d7e9f0a2b4c6d8e0f1a3b5c7d9e"
+	for name, data := range map[string]string{
+		"containerd": `1012 993 0:221 / / rw,relatime master:350 - overlay overlay rw,lowerdir=/var/lib/containerd/io.containerd.snapshotter.v1.overlayfs/snapshots/412/fs,upperdir=/var/lib/containerd/io.containerd.snapshotter.v1.overlayfs/snapshots/413/fs
+1023 1012 259:1 /var/lib/kubelet/pods/0b3b3b6c-1d6e-4b8a-9d49-1e0f9e3c2a41/etc-hosts /etc/hosts rw,noatime - xfs /dev/nvme0n1p1 rw
+1024 1012 259:1 /var/lib/kubelet/pods/0b3b3b6c-1d6e-4b8a-9d49-1e0f9e3c2a41/containers/app/4f1c2a7e /dev/termination-log rw,noatime - xfs /dev/nvme0n1p1 rw
+1025 1012 259:1 /var/lib/containerd/io.containerd.grpc.v1.cri/sandboxes/` + sandboxID + `/hostname /etc/hostname rw,noatime - xfs /dev/nvme0n1p1 rw
+1026 1012 259:1 /var/lib/containerd/io.containerd.grpc.v1.cri/sandboxes/` + sandboxID + `/resolv.conf /etc/resolv.conf rw,noatime - xfs /dev/nvme0n1p1 rw`,
+		"cri-o": `1012 993 0:221 / / rw,relatime - overlay overlay rw
+1025 1012 0:25 /containers/storage/overlay-containers/` + sandboxID + `/userdata/hostname /etc/hostname rw,nosuid,nodev - tmpfs tmpfs rw`,
+	} {
+		assert.Equal(t, "", ContainerIDFromMountinfo(data), name)
+	}
+}
+
+func TestContainerID(t *testing.T) {
+	dir := t.TempDir()
+	cgroupPath := filepath.Join(dir, "cgroup")
+	mountinfoPath := filepath.Join(dir, "mountinfo")
+
+	assert.Equal(t, "", ContainerID(cgroupPath, mountinfoPath), "missing files")
+
+	require.NoError(t, os.WriteFile(cgroupPath, []byte("0::/\n"), 0o600))
+	require.NoError(t, os.WriteFile(mountinfoPath, []byte("749 736 259:1 /var/lib/docker/containers/"+containerID+"/hostname /etc/hostname rw,relatime - ext4 /dev/root rw\n"), 0o600))
+	assert.Equal(t, containerID, ContainerID(cgroupPath, mountinfoPath), "cgroup v2")
+
+	require.NoError(t, os.WriteFile(cgroupPath, []byte("12:pids:/docker/"+containerID+"\n"), 0o600))
+	require.NoError(t, os.Remove(mountinfoPath))
+	assert.Equal(t, containerID, ContainerID(cgroupPath, mountinfoPath), "cgroup v1")
+}
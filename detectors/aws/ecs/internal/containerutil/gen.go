@@ -0,0 +1,19 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package containerutil // import "go.opentelemetry.io/contrib/detectors/aws/ecs/internal/containerutil"
+
+// Generate containerutil package:
+//go:generate gotmpl --body=../../../../../internal/shared/containerutil/container_test.go.tmpl "--data={}" --out=container_test.go
+//go:generate gotmpl --body=../../../../../internal/shared/containerutil/container.go.tmpl "--data={}" --out=container.go
@@ -0,0 +1,25 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eks // import "go.opentelemetry.io/contrib/detectors/aws/eks"
+
+import "go.opentelemetry.io/contrib/detectors/aws/eks/internal/containerutil"
+
+// getContainerID returns the containerID if currently running within a
+// container. An empty string is returned if it is not found, e.g. with
+// containerd and CRI-O on cgroup v2 nodes, which do not expose it to the
+// container.
+func (eksUtils eksDetectorUtils) getContainerID() (string, error) {
+	return containerutil.ContainerID(eksUtils.cgroupPath, eksUtils.mountinfoPath), nil
+}
@@ -18,13 +18,12 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"regexp"
-	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 
+	"go.opentelemetry.io/contrib/detectors/aws/eks/internal/containerutil"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/sdk/resource"
 	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
@@ -37,8 +36,6 @@ const (
 	authConfigmapName = "aws-auth"
 	cwConfigmapNS     = "amazon-cloudwatch"
 	cwConfigmapName   = "cluster-info"
-	defaultCgroupPath = "/proc/self/cgroup"
-	containerIDLength = 64
 )
 
 // detectorUtils is used for testing the resourceDetector by abstracting functions that rely on external systems.
@@ -46,17 +43,95 @@ type detectorUtils interface {
 	fileExists(filename string) bool
 	getConfigMap(ctx context.Context, namespace string, name string) (map[string]string, error)
 	getContainerID() (string, error)
+	getNamespace() (string, error)
+	getPod(ctx context.Context, namespace string, name string) (*podInfo, error)
 }
 
 // This struct will implement the detectorUtils interface.
 type eksDetectorUtils struct {
-	clientset *kubernetes.Clientset
+	clientset     kubernetes.Interface
+	cgroupPath    string
+	mountinfoPath string
+}
+
+// newEKSDetectorUtils returns the eksDetectorUtils querying the Kubernetes
+// API with clientset.
+func newEKSDetectorUtils(clientset kubernetes.Interface) *eksDetectorUtils {
+	return &eksDetectorUtils{
+		clientset:     clientset,
+		cgroupPath:    containerutil.DefaultCgroupPath,
+		mountinfoPath: containerutil.DefaultMountinfoPath,
+	}
+}
+
+type config struct {
+	podAttributes bool
+	kubernetesAPI bool
+	clientset     kubernetes.Interface
+}
+
+// newConfig returns an appropriately configured config.
+func newConfig(options ...Option) *config {
+	c := new(config)
+	for _, option := range options {
+		option.apply(c)
+	}
+
+	return c
+}
+
+// Option applies an EKS detector configuration option.
+type Option interface {
+	apply(*config)
+}
+
+type optionFunc func(*config)
+
+func (fn optionFunc) apply(c *config) {
+	fn(c)
+}
+
+// WithPodAttributes enables the detection of the k8s.pod.name,
+// k8s.pod.uid, k8s.namespace.name, k8s.node.name and k8s.deployment.name
+// attributes. They are read from the environment variables set with the
+// Kubernetes downward API, falling back to the hostname for the pod name and
+// to the service account namespace for the namespace.
+//
+// The attributes that are not set in the environment, including the
+// deployment owning the pod, are only detected when the Kubernetes API is
+// used, see WithKubernetesAPI.
+func WithPodAttributes() Option {
+	return optionFunc(func(c *config) {
+		c.podAttributes = true
+	})
+}
+
+// WithKubernetesAPI enables resolving the pod attributes the downward API
+// does not provide from the Kubernetes API. It requires the service account
+// of the pod to be allowed to get pods and replicasets in its namespace. It
+// implies WithPodAttributes.
+func WithKubernetesAPI() Option {
+	return optionFunc(func(c *config) {
+		c.podAttributes = true
+		c.kubernetesAPI = true
+	})
+}
+
+// WithKubernetesClient sets the client used to access the Kubernetes API. If
+// this option is not provided, a client is created from the in-cluster
+// configuration.
+func WithKubernetesClient(clientset kubernetes.Interface) Option {
+	return optionFunc(func(c *config) {
+		c.clientset = clientset
+	})
 }
 
 // resourceDetector for detecting resources running on Amazon EKS.
 type resourceDetector struct {
-	utils detectorUtils
-	err   error
+	utils         detectorUtils
+	err           error
+	podAttributes bool
+	kubernetesAPI bool
 }
 
 // Compile time assertion that resourceDetector implements the resource.Detector interface.
@@ -66,12 +141,23 @@ var _ resource.Detector = (*resourceDetector)(nil)
 var _ detectorUtils = (*eksDetectorUtils)(nil)
 
 // NewResourceDetector returns a resource detector that will detect AWS EKS resources.
-func NewResourceDetector() resource.Detector {
-	utils, err := newK8sDetectorUtils()
-	return &resourceDetector{utils: utils, err: err}
+func NewResourceDetector(opts ...Option) resource.Detector {
+	c := newConfig(opts...)
+	detector := &resourceDetector{
+		podAttributes: c.podAttributes,
+		kubernetesAPI: c.kubernetesAPI,
+	}
+	if c.clientset != nil {
+		detector.utils = newEKSDetectorUtils(c.clientset)
+	} else {
+		detector.utils, detector.err = newK8sDetectorUtils()
+	}
+	return detector
 }
 
 // Detect returns a Resource describing the Amazon EKS environment being run in.
+// If some pod attributes cannot be resolved, the Resource holding the other
+// attributes is returned with an error wrapping resource.ErrPartialResource.
 func (detector *resourceDetector) Detect(ctx context.Context) (*resource.Resource, error) {
 	if detector.err != nil {
 		return nil, detector.err
@@ -111,7 +197,14 @@ func (detector *resourceDetector) Detect(ctx context.Context) (*resource.Resourc
 		attributes = append(attributes, semconv.ContainerID(containerID))
 	}
 
-	// Return new resource object with clusterName and containerID as attributes
+	if detector.podAttributes {
+		podAttributes, err := detector.getPodAttributes(ctx)
+		attributes = append(attributes, podAttributes...)
+		if err != nil {
+			return resource.NewWithAttributes(semconv.SchemaURL, attributes...), fmt.Errorf("%w: %w", resource.ErrPartialResource, err)
+		}
+	}
+
 	return resource.NewWithAttributes(semconv.SchemaURL, attributes...), nil
 }
 
@@ -144,7 +237,7 @@ func newK8sDetectorUtils() (*eksDetectorUtils, error) {
 		return nil, fmt.Errorf("failed to create clientset for Kubernetes client")
 	}
 
-	return &eksDetectorUtils{clientset: clientset}, nil
+	return newEKSDetectorUtils(clientset), nil
 }
 
 // isK8s checks if the current environment is running in a Kubernetes environment.
@@ -177,26 +270,3 @@ func getClusterName(ctx context.Context, utils detectorUtils) (string, error) {
 
 	return resp["cluster.name"], nil
 }
-
-// getContainerID returns the containerID if currently running within a container.
-func (eksUtils eksDetectorUtils) getContainerID() (string, error) {
-	fileData, err := os.ReadFile(defaultCgroupPath)
-	if err != nil {
-		return "", fmt.Errorf("getContainerID() error: cannot read file with path %s: %w", defaultCgroupPath, err)
-	}
-
-	// is this going to stop working with 1.20 when Docker is deprecated?
-	r, err := regexp.Compile(`^.*/docker/(.+)$`)
-	if err != nil {
-		return "", err
-	}
-
-	// Retrieve containerID from file
-	splitData := strings.Split(strings.TrimSpace(string(fileData)), "\n")
-	for _, str := range splitData {
-		if r.MatchString(str) {
-			return str[len(str)-containerIDLength:], nil
-		}
-	}
-	return "", fmt.Errorf("getContainerID() error: cannot read containerID from file %s", defaultCgroupPath)
-}
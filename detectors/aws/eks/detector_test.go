@@ -16,11 +16,20 @@ package eks
 
 import (
 	"context"
+	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/sdk/resource"
@@ -49,6 +58,18 @@ func (detectorUtils *MockDetectorUtils) getContainerID() (string, error) {
 	return args.String(0), args.Error(1)
 }
 
+// Mock function for getNamespace().
+func (detectorUtils *MockDetectorUtils) getNamespace() (string, error) {
+	args := detectorUtils.Called()
+	return args.String(0), args.Error(1)
+}
+
+// Mock function for getPod().
+func (detectorUtils *MockDetectorUtils) getPod(_ context.Context, namespace string, name string) (*podInfo, error) {
+	args := detectorUtils.Called(namespace, name)
+	return args.Get(0).(*podInfo), args.Error(1)
+}
+
 // Tests EKS resource detector running in EKS environment.
 func TestEks(t *testing.T) {
 	detectorUtils := new(MockDetectorUtils)
@@ -78,6 +99,57 @@ func TestEks(t *testing.T) {
 	detectorUtils.AssertExpectations(t)
 }
 
+// k8sFilesUtils is the eksDetectorUtils of a process running in Kubernetes,
+// whose service account files exist.
+type k8sFilesUtils struct {
+	*eksDetectorUtils
+}
+
+func (k8sFilesUtils) fileExists(string) bool { return true }
+
+// Tests EKS resource detector running in a containerd or CRI-O container on
+// a cgroup v2 node, where the container ID is not exposed.
+func TestEksCgroupV2(t *testing.T) {
+	t.Setenv("K8S_POD_NAME", "my-pod")
+	t.Setenv("K8S_NAMESPACE_NAME", "my-namespace")
+
+	dir := t.TempDir()
+	utils := newEKSDetectorUtils(fake.NewSimpleClientset(
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: authConfigmapName, Namespace: authConfigmapNS},
+			Data:       map[string]string{"mapRoles": ""},
+		},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: cwConfigmapName, Namespace: cwConfigmapNS},
+			Data:       map[string]string{"cluster.name": "my-cluster"},
+		},
+	))
+	utils.cgroupPath = filepath.Join(dir, "cgroup")
+	utils.mountinfoPath = filepath.Join(dir, "mountinfo")
+	require.NoError(t, os.WriteFile(utils.cgroupPath, []byte("0::/\n"), 0o600))
+	require.NoError(t, os.WriteFile(utils.mountinfoPath, []byte(
+		"1 0 259:1 /var/lib/containerd/io.containerd.grpc.v1.cri/sandboxes/"+
+			"3f8c1ee2f3d8b1c62b83d0bd8ed12e5d3dc2f6f5b1e6bca3a0dfb2c6a5f0e1d2/hostname "+
+			"/etc/hostname rw,relatime - ext4 /dev/nvme0n1p1 rw\n"), 0o600))
+
+	id, err := utils.getContainerID()
+	require.NoError(t, err)
+	assert.Empty(t, id)
+
+	detector := resourceDetector{utils: k8sFilesUtils{utils}, podAttributes: true}
+	r, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+
+	expected := resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.CloudProviderAWS,
+		semconv.CloudPlatformAWSEKS,
+		semconv.K8SClusterName("my-cluster"),
+		semconv.K8SPodName("my-pod"),
+		semconv.K8SNamespaceName("my-namespace"),
+	)
+	assert.Equal(t, expected, r)
+}
+
 // Tests EKS resource detector not running in EKS environment.
 func TestNotEKS(t *testing.T) {
 	detectorUtils := new(MockDetectorUtils)
@@ -93,3 +165,220 @@ func TestNotEKS(t *testing.T) {
 	assert.Equal(t, resource.Empty(), r, "Resource object should be empty")
 	detectorUtils.AssertExpectations(t)
 }
+
+// Tests EKS resource detector resolving the pod attributes.
+func TestEksPodAttributes(t *testing.T) {
+	t.Setenv("K8S_POD_NAME", "my-pod-5d4f8b-x2x9z")
+	t.Setenv("POD_NAME", "ignored")
+	t.Setenv("K8S_NODE_NAME", "my-node")
+
+	detectorUtils := new(MockDetectorUtils)
+	detectorUtils.On("fileExists", k8sTokenPath).Return(true)
+	detectorUtils.On("fileExists", k8sCertPath).Return(true)
+	detectorUtils.On("getConfigMap", authConfigmapNS, authConfigmapName).Return(map[string]string{"not": "nil"}, nil)
+	detectorUtils.On("getConfigMap", cwConfigmapNS, cwConfigmapName).Return(map[string]string{"cluster.name": "my-cluster"}, nil)
+	detectorUtils.On("getContainerID").Return("0123456789A", nil)
+	detectorUtils.On("getNamespace").Return("my-namespace", nil)
+	detectorUtils.On("getPod", "my-namespace", "my-pod-5d4f8b-x2x9z").Return(&podInfo{
+		uid:            "c5b9b3b6-1d6e-4b8a-9d49-1e0f9e3c2a41",
+		nodeName:       "other-node",
+		deploymentName: "my-pod",
+	}, nil)
+
+	detector := resourceDetector{utils: detectorUtils, podAttributes: true, kubernetesAPI: true}
+	r, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+
+	expected := resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.CloudProviderAWS,
+		semconv.CloudPlatformAWSEKS,
+		semconv.K8SClusterName("my-cluster"),
+		semconv.ContainerID("0123456789A"),
+		semconv.K8SPodName("my-pod-5d4f8b-x2x9z"),
+		semconv.K8SPodUID("c5b9b3b6-1d6e-4b8a-9d49-1e0f9e3c2a41"),
+		semconv.K8SNamespaceName("my-namespace"),
+		semconv.K8SNodeName("my-node"),
+		semconv.K8SDeploymentName("my-pod"),
+	)
+	assert.Equal(t, expected, r)
+	detectorUtils.AssertExpectations(t)
+}
+
+// Tests EKS resource detector returning a partial resource when the pod
+// cannot be retrieved from the Kubernetes API.
+func TestEksPodAttributesPartial(t *testing.T) {
+	t.Setenv("K8S_POD_NAME", "my-pod")
+	t.Setenv("K8S_NAMESPACE_NAME", "")
+	t.Setenv("POD_NAMESPACE", "")
+	t.Setenv("NAMESPACE_NAME", "")
+
+	detectorUtils := new(MockDetectorUtils)
+	detectorUtils.On("fileExists", k8sTokenPath).Return(true)
+	detectorUtils.On("fileExists", k8sCertPath).Return(true)
+	detectorUtils.On("getConfigMap", authConfigmapNS, authConfigmapName).Return(map[string]string{"not": "nil"}, nil)
+	detectorUtils.On("getConfigMap", cwConfigmapNS, cwConfigmapName).Return(map[string]string{"cluster.name": "my-cluster"}, nil)
+	detectorUtils.On("getContainerID").Return("0123456789A", nil)
+	detectorUtils.On("getNamespace").Return("my-namespace", nil)
+	errForbidden := errors.New("pods is forbidden")
+	detectorUtils.On("getPod", "my-namespace", "my-pod").Return((*podInfo)(nil), errForbidden)
+
+	detector := resourceDetector{utils: detectorUtils, podAttributes: true, kubernetesAPI: true}
+	r, err := detector.Detect(context.Background())
+	assert.ErrorIs(t, err, resource.ErrPartialResource)
+	assert.ErrorIs(t, err, errForbidden)
+
+	expected := resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.CloudProviderAWS,
+		semconv.CloudPlatformAWSEKS,
+		semconv.K8SClusterName("my-cluster"),
+		semconv.ContainerID("0123456789A"),
+		semconv.K8SPodName("my-pod"),
+		semconv.K8SNamespaceName("my-namespace"),
+	)
+	assert.Equal(t, expected, r)
+	detectorUtils.AssertExpectations(t)
+}
+
+// Tests EKS resource detector keeping the pod attributes retrieved from the
+// Kubernetes API when the ReplicaSet of the pod cannot be retrieved.
+func TestEksPodAttributesReplicaSetForbidden(t *testing.T) {
+	t.Setenv("K8S_POD_NAME", "my-pod-5d4f8b-x2x9z")
+	t.Setenv("K8S_NAMESPACE_NAME", "my-namespace")
+
+	detectorUtils := new(MockDetectorUtils)
+	detectorUtils.On("fileExists", k8sTokenPath).Return(true)
+	detectorUtils.On("fileExists", k8sCertPath).Return(true)
+	detectorUtils.On("getConfigMap", authConfigmapNS, authConfigmapName).Return(map[string]string{"not": "nil"}, nil)
+	detectorUtils.On("getConfigMap", cwConfigmapNS, cwConfigmapName).Return(map[string]string{}, nil)
+	detectorUtils.On("getContainerID").Return("", nil)
+	errForbidden := errors.New("replicasets.apps is forbidden")
+	detectorUtils.On("getPod", "my-namespace", "my-pod-5d4f8b-x2x9z").Return(&podInfo{
+		uid:      "c5b9b3b6-1d6e-4b8a-9d49-1e0f9e3c2a41",
+		nodeName: "my-node",
+	}, errForbidden)
+
+	detector := resourceDetector{utils: detectorUtils, podAttributes: true, kubernetesAPI: true}
+	r, err := detector.Detect(context.Background())
+	assert.ErrorIs(t, err, resource.ErrPartialResource)
+	assert.ErrorIs(t, err, errForbidden)
+
+	expected := resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.CloudProviderAWS,
+		semconv.CloudPlatformAWSEKS,
+		semconv.K8SPodName("my-pod-5d4f8b-x2x9z"),
+		semconv.K8SPodUID("c5b9b3b6-1d6e-4b8a-9d49-1e0f9e3c2a41"),
+		semconv.K8SNamespaceName("my-namespace"),
+		semconv.K8SNodeName("my-node"),
+	)
+	assert.Equal(t, expected, r)
+	detectorUtils.AssertExpectations(t)
+}
+
+// Tests EKS resource detector reading the pod attributes from the downward API only.
+func TestEksPodAttributesDownwardAPI(t *testing.T) {
+	t.Setenv("POD_NAME", "my-pod")
+	t.Setenv("POD_NAMESPACE", "my-namespace")
+	t.Setenv("K8S_DEPLOYMENT_NAME", "my-deployment")
+
+	detectorUtils := new(MockDetectorUtils)
+	detectorUtils.On("fileExists", k8sTokenPath).Return(true)
+	detectorUtils.On("fileExists", k8sCertPath).Return(true)
+	detectorUtils.On("getConfigMap", authConfigmapNS, authConfigmapName).Return(map[string]string{"not": "nil"}, nil)
+	detectorUtils.On("getConfigMap", cwConfigmapNS, cwConfigmapName).Return(map[string]string{}, nil)
+	detectorUtils.On("getContainerID").Return("", nil)
+
+	detector := resourceDetector{utils: detectorUtils, podAttributes: true}
+	r, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+
+	expected := resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.CloudProviderAWS,
+		semconv.CloudPlatformAWSEKS,
+		semconv.K8SPodName("my-pod"),
+		semconv.K8SNamespaceName("my-namespace"),
+		semconv.K8SDeploymentName("my-deployment"),
+	)
+	assert.Equal(t, expected, r)
+	detectorUtils.AssertExpectations(t)
+}
+
+func TestGetPod(t *testing.T) {
+	isController := true
+	clientset := fake.NewSimpleClientset(
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-pod-5d4f8b-x2x9z",
+				Namespace: "my-namespace",
+				UID:       "c5b9b3b6-1d6e-4b8a-9d49-1e0f9e3c2a41",
+				OwnerReferences: []metav1.OwnerReference{
+					{Kind: "ReplicaSet", Name: "my-pod-5d4f8b", Controller: &isController},
+				},
+			},
+			Spec: corev1.PodSpec{NodeName: "my-node"},
+		},
+		&appsv1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-pod-5d4f8b",
+				Namespace: "my-namespace",
+				OwnerReferences: []metav1.OwnerReference{
+					{Kind: "Deployment", Name: "my-pod", Controller: &isController},
+				},
+			},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "standalone", Namespace: "my-namespace", UID: "1"},
+		},
+	)
+	utils := eksDetectorUtils{clientset: clientset}
+
+	info, err := utils.getPod(context.Background(), "my-namespace", "my-pod-5d4f8b-x2x9z")
+	require.NoError(t, err)
+	assert.Equal(t, &podInfo{
+		uid:            "c5b9b3b6-1d6e-4b8a-9d49-1e0f9e3c2a41",
+		nodeName:       "my-node",
+		deploymentName: "my-pod",
+	}, info)
+
+	info, err = utils.getPod(context.Background(), "my-namespace", "standalone")
+	require.NoError(t, err)
+	assert.Equal(t, &podInfo{uid: "1"}, info)
+
+	_, err = utils.getPod(context.Background(), "my-namespace", "unknown")
+	assert.Error(t, err)
+}
+
+func TestGetPodReplicaSetForbidden(t *testing.T) {
+	isController := true
+	clientset := fake.NewSimpleClientset(&corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-pod-5d4f8b-x2x9z",
+			Namespace: "my-namespace",
+			UID:       "c5b9b3b6-1d6e-4b8a-9d49-1e0f9e3c2a41",
+			OwnerReferences: []metav1.OwnerReference{
+				{Kind: "ReplicaSet", Name: "my-pod-5d4f8b", Controller: &isController},
+			},
+		},
+		Spec: corev1.PodSpec{NodeName: "my-node"},
+	})
+	errForbidden := errors.New("replicasets.apps is forbidden")
+	clientset.PrependReactor("get", "replicasets", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errForbidden
+	})
+	utils := eksDetectorUtils{clientset: clientset}
+
+	info, err := utils.getPod(context.Background(), "my-namespace", "my-pod-5d4f8b-x2x9z")
+	assert.ErrorIs(t, err, errForbidden)
+	assert.Equal(t, &podInfo{
+		uid:      "c5b9b3b6-1d6e-4b8a-9d49-1e0f9e3c2a41",
+		nodeName: "my-node",
+	}, info)
+}
+
+func TestNewResourceDetectorWithKubernetesClient(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+	detector := NewResourceDetector(WithKubernetesClient(clientset), WithKubernetesAPI()).(*resourceDetector)
+	require.NoError(t, detector.err)
+	assert.Equal(t, newEKSDetectorUtils(clientset), detector.utils)
+	assert.True(t, detector.podAttributes)
+	assert.True(t, detector.kubernetesAPI)
+}
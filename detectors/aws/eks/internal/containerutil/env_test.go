@@ -0,0 +1,47 @@
+// Code created by gotmpl. DO NOT MODIFY.
+// source: internal/shared/containerutil/env_test.go.tmpl
+
+// Copyright The OpenTelemetry Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package containerutil
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLookupEnv(t *testing.T) {
+	t.Setenv("CONTAINERUTIL_TEST_A", "")
+	t.Setenv("CONTAINERUTIL_TEST_B", "b")
+	t.Setenv("CONTAINERUTIL_TEST_C", "c")
+
+	assert.Equal(t, "b", LookupEnv("CONTAINERUTIL_TEST_A", "CONTAINERUTIL_TEST_B", "CONTAINERUTIL_TEST_C"))
+	assert.Equal(t, "", LookupEnv("CONTAINERUTIL_TEST_A"))
+	assert.Equal(t, "", LookupEnv())
+}
+
+func TestPodName(t *testing.T) {
+	t.Setenv("CONTAINERUTIL_TEST_POD_NAME", "")
+	hostname := func() (string, error) { return "my-pod-5d4f8b-x2x9z", nil }
+	failing := func() (string, error) { return "", errors.New("no hostname") }
+
+	assert.Equal(t, "my-pod-5d4f8b-x2x9z", PodName(hostname, "CONTAINERUTIL_TEST_POD_NAME"))
+	assert.Equal(t, "", PodName(failing, "CONTAINERUTIL_TEST_POD_NAME"))
+	assert.Equal(t, "", PodName(nil, "CONTAINERUTIL_TEST_POD_NAME"))
+
+	t.Setenv("CONTAINERUTIL_TEST_POD_NAME", "my-pod")
+	assert.Equal(t, "my-pod", PodName(hostname, "CONTAINERUTIL_TEST_POD_NAME"))
+}
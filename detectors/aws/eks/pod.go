@@ -0,0 +1,133 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eks // import "go.opentelemetry.io/contrib/detectors/aws/eks"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"go.opentelemetry.io/contrib/detectors/aws/eks/internal/containerutil"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+)
+
+const k8sNamespacePath = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+
+// podInfo holds the pod metadata resolved from the Kubernetes API.
+type podInfo struct {
+	uid            string
+	nodeName       string
+	deploymentName string
+}
+
+// getPodAttributes returns the k8s.* attributes of the pod the process runs in.
+// The attributes resolved are returned along with the errors met resolving
+// the others.
+func (detector *resourceDetector) getPodAttributes(ctx context.Context) ([]attribute.KeyValue, error) {
+	podName := containerutil.PodName(os.Hostname, containerutil.PodNameEnvVars...)
+	var errs []error
+	namespace := containerutil.LookupEnv(containerutil.NamespaceEnvVars...)
+	if namespace == "" {
+		var err error
+		if namespace, err = detector.utils.getNamespace(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	info := &podInfo{
+		uid:            containerutil.LookupEnv(containerutil.PodUIDEnvVars...),
+		nodeName:       containerutil.LookupEnv(containerutil.NodeNameEnvVars...),
+		deploymentName: containerutil.LookupEnv(containerutil.DeploymentNameEnvVars...),
+	}
+
+	if detector.kubernetesAPI && podName != "" && namespace != "" &&
+		(info.uid == "" || info.nodeName == "" || info.deploymentName == "") {
+		pod, err := detector.utils.getPod(ctx, namespace, podName)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		// The pod metadata resolved is used even if the ReplicaSet of the
+		// pod could not be retrieved.
+		if pod != nil {
+			if info.uid == "" {
+				info.uid = pod.uid
+			}
+			if info.nodeName == "" {
+				info.nodeName = pod.nodeName
+			}
+			if info.deploymentName == "" {
+				info.deploymentName = pod.deploymentName
+			}
+		}
+	}
+
+	var attributes []attribute.KeyValue
+	if podName != "" {
+		attributes = append(attributes, semconv.K8SPodName(podName))
+	}
+	if info.uid != "" {
+		attributes = append(attributes, semconv.K8SPodUID(info.uid))
+	}
+	if namespace != "" {
+		attributes = append(attributes, semconv.K8SNamespaceName(namespace))
+	}
+	if info.nodeName != "" {
+		attributes = append(attributes, semconv.K8SNodeName(info.nodeName))
+	}
+	if info.deploymentName != "" {
+		attributes = append(attributes, semconv.K8SDeploymentName(info.deploymentName))
+	}
+	return attributes, errors.Join(errs...)
+}
+
+// getNamespace returns the namespace of the service account of the pod.
+func (eksUtils eksDetectorUtils) getNamespace() (string, error) {
+	data, err := os.ReadFile(k8sNamespacePath)
+	if err != nil {
+		return "", fmt.Errorf("getNamespace() error: cannot read file with path %s: %w", k8sNamespacePath, err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// getPod retrieves the pod metadata from the k8s API. The deployment of the
+// pod is resolved from the ReplicaSet controlling it. If the ReplicaSet cannot
+// be retrieved, the pod metadata is returned along with the error.
+func (eksUtils eksDetectorUtils) getPod(ctx context.Context, namespace string, name string) (*podInfo, error) {
+	pod, err := eksUtils.clientset.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve Pod %s/%s: %w", namespace, name, err)
+	}
+	info := &podInfo{
+		uid:      string(pod.UID),
+		nodeName: pod.Spec.NodeName,
+	}
+
+	owner := metav1.GetControllerOf(pod)
+	if owner == nil || owner.Kind != "ReplicaSet" {
+		return info, nil
+	}
+	rs, err := eksUtils.clientset.AppsV1().ReplicaSets(namespace).Get(ctx, owner.Name, metav1.GetOptions{})
+	if err != nil {
+		return info, fmt.Errorf("failed to retrieve ReplicaSet %s/%s: %w", namespace, owner.Name, err)
+	}
+	if owner = metav1.GetControllerOf(rs); owner != nil && owner.Kind == "Deployment" {
+		info.deploymentName = owner.Name
+	}
+	return info, nil
+}
@@ -19,6 +19,9 @@ import (
 	"errors"
 	"os"
 	"strconv"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/lambdacontext"
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/sdk/resource"
@@ -33,6 +36,7 @@ const (
 	lambdaFunctionVersionEnvVar = "AWS_LAMBDA_FUNCTION_VERSION"
 	lambdaLogStreamNameEnvVar   = "AWS_LAMBDA_LOG_STREAM_NAME"
 	lambdaMemoryLimitEnvVar     = "AWS_LAMBDA_FUNCTION_MEMORY_SIZE"
+	lambdaLogGroupNameEnvVar    = "AWS_LAMBDA_LOG_GROUP_NAME"
 )
 
 var (
@@ -40,19 +44,62 @@ var (
 	errNotOnLambda = errors.New("process is not on Lambda, cannot detect environment variables from Lambda")
 )
 
+type config struct {
+	logAttributes bool
+}
+
+// newConfig returns an appropriately configured config.
+func newConfig(options ...Option) *config {
+	c := new(config)
+	for _, option := range options {
+		option.apply(c)
+	}
+
+	return c
+}
+
+// Option applies a Lambda detector configuration option.
+type Option interface {
+	apply(*config)
+}
+
+type optionFunc func(*config)
+
+func (fn optionFunc) apply(c *config) {
+	fn(c)
+}
+
+// WithLogAttributes enables the detection of the CloudWatch Logs log group
+// and log stream of the function, as the aws.log.group.names and
+// aws.log.stream.names attributes. Their ARNs are detected as well when the
+// account of the function is known, see Detect.
+func WithLogAttributes() Option {
+	return optionFunc(func(c *config) {
+		c.logAttributes = true
+	})
+}
+
 // resource detector collects resource information from Lambda environment.
-type resourceDetector struct{}
+type resourceDetector struct {
+	logAttributes bool
+}
 
 // compile time assertion that resource detector implements the resource.Detector interface.
 var _ resource.Detector = (*resourceDetector)(nil)
 
 // NewResourceDetector returns a resource detector that will detect AWS Lambda resources.
-func NewResourceDetector() resource.Detector {
-	return &resourceDetector{}
+func NewResourceDetector(opts ...Option) resource.Detector {
+	c := newConfig(opts...)
+	return &resourceDetector{logAttributes: c.logAttributes}
 }
 
 // Detect collects resource attributes available when running on lambda.
-func (detector *resourceDetector) Detect(context.Context) (*resource.Resource, error) {
+//
+// The account of the function and its ARN are only known once the function
+// is invoked. If ctx is the context of an invocation, holding a
+// lambdacontext.LambdaContext, the cloud.account.id and cloud.resource_id
+// attributes are detected as well.
+func (detector *resourceDetector) Detect(ctx context.Context) (*resource.Resource, error) {
 	// Lambda resources come from ENV
 	lambdaName := os.Getenv(lambdaFunctionNameEnvVar)
 	if len(lambdaName) == 0 {
@@ -78,5 +125,52 @@ func (detector *resourceDetector) Detect(context.Context) (*resource.Resource, e
 		attrs = append(attrs, semconv.FaaSMaxMemory(maxMemory))
 	}
 
+	var partition, account string
+	if lc, ok := lambdacontext.FromContext(ctx); ok {
+		// The invoked ARN has the form
+		// arn:<partition>:lambda:<region>:<account>:function:<name>[:<qualifier>]
+		arnParts := strings.Split(lc.InvokedFunctionArn, ":")
+		if len(arnParts) >= 7 && arnParts[0] == "arn" {
+			partition, account = arnParts[1], arnParts[4]
+			attrs = append(attrs,
+				semconv.CloudAccountID(account),
+				semconv.CloudResourceID(strings.Join(arnParts[:7], ":")),
+			)
+		}
+	}
+
+	if detector.logAttributes {
+		attrs = append(attrs, logAttributes(partition, awsRegion, account, instance)...)
+	}
+
 	return resource.NewWithAttributes(semconv.SchemaURL, attrs...), nil
 }
+
+// logAttributes returns the attributes of the log group and log stream of the
+// function. The ARNs are only returned if partition and account are known.
+func logAttributes(partition, region, account, logStream string) []attribute.KeyValue {
+	logGroup := os.Getenv(lambdaLogGroupNameEnvVar)
+	if logGroup == "" {
+		return nil
+	}
+
+	attrs := []attribute.KeyValue{semconv.AWSLogGroupNames(logGroup)}
+	if logStream != "" {
+		attrs = append(attrs, semconv.AWSLogStreamNames(logStream))
+	}
+	if partition == "" || account == "" {
+		return attrs
+	}
+
+	logGroupArn := strings.Join([]string{
+		"arn", partition, "logs", region, account, "log-group", logGroup, "*",
+	}, ":")
+	attrs = append(attrs, semconv.AWSLogGroupARNs(logGroupArn))
+	if logStream != "" {
+		logStreamArn := strings.Join([]string{
+			"arn", partition, "logs", region, account, "log-group", logGroup, "log-stream", logStream,
+		}, ":")
+		attrs = append(attrs, semconv.AWSLogStreamARNs(logStreamArn))
+	}
+	return attrs
+}
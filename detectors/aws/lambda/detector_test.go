@@ -19,6 +19,7 @@ import (
 	"os"
 	"testing"
 
+	"github.com/aws/aws-lambda-go/lambdacontext"
 	"github.com/stretchr/testify/assert"
 
 	"go.opentelemetry.io/otel/attribute"
@@ -59,3 +60,73 @@ func TestReturnsIfNoEnvVars(t *testing.T) {
 	assert.Equal(t, errNotOnLambda, err)
 	assert.Equal(t, 0, len(res.Attributes()))
 }
+
+func TestDetectInvocationContext(t *testing.T) {
+	t.Setenv(lambdaFunctionNameEnvVar, "testFunction")
+	t.Setenv(awsRegionEnvVar, "us-texas-1")
+	t.Setenv(lambdaFunctionVersionEnvVar, "$LATEST")
+	t.Setenv(lambdaLogStreamNameEnvVar, "2023/01/01/[$LATEST]5d1edb9e525d486696cf01a3503487bc")
+	t.Setenv(lambdaMemoryLimitEnvVar, "128")
+	t.Setenv(lambdaLogGroupNameEnvVar, "/aws/lambda/testFunction")
+
+	ctx := lambdacontext.NewContext(context.Background(), &lambdacontext.LambdaContext{
+		InvokedFunctionArn: "arn:aws:lambda:us-texas-1:123456789012:function:testFunction:alias",
+	})
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		opts []Option
+		want []attribute.KeyValue
+	}{
+		{
+			name: "invocation context",
+			ctx:  ctx,
+			want: []attribute.KeyValue{
+				semconv.CloudAccountID("123456789012"),
+				semconv.CloudResourceID("arn:aws:lambda:us-texas-1:123456789012:function:testFunction"),
+			},
+		},
+		{
+			name: "log attributes",
+			ctx:  context.Background(),
+			opts: []Option{WithLogAttributes()},
+			want: []attribute.KeyValue{
+				semconv.AWSLogGroupNames("/aws/lambda/testFunction"),
+				semconv.AWSLogStreamNames("2023/01/01/[$LATEST]5d1edb9e525d486696cf01a3503487bc"),
+			},
+		},
+		{
+			name: "log attributes with invocation context",
+			ctx:  ctx,
+			opts: []Option{WithLogAttributes()},
+			want: []attribute.KeyValue{
+				semconv.CloudAccountID("123456789012"),
+				semconv.CloudResourceID("arn:aws:lambda:us-texas-1:123456789012:function:testFunction"),
+				semconv.AWSLogGroupNames("/aws/lambda/testFunction"),
+				semconv.AWSLogStreamNames("2023/01/01/[$LATEST]5d1edb9e525d486696cf01a3503487bc"),
+				semconv.AWSLogGroupARNs("arn:aws:logs:us-texas-1:123456789012:log-group:/aws/lambda/testFunction:*"),
+				semconv.AWSLogStreamARNs("arn:aws:logs:us-texas-1:123456789012:log-group:/aws/lambda/testFunction:log-stream:2023/01/01/[$LATEST]5d1edb9e525d486696cf01a3503487bc"),
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			attributes := append([]attribute.KeyValue{
+				semconv.CloudProviderAWS,
+				semconv.CloudRegion("us-texas-1"),
+				semconv.FaaSName("testFunction"),
+				semconv.FaaSVersion("$LATEST"),
+				semconv.FaaSInstance("2023/01/01/[$LATEST]5d1edb9e525d486696cf01a3503487bc"),
+				semconv.FaaSMaxMemory(128),
+			}, tc.want...)
+			expectedResource := resource.NewWithAttributes(semconv.SchemaURL, attributes...)
+
+			res, err := NewResourceDetector(tc.opts...).Detect(tc.ctx)
+
+			assert.NoError(t, err)
+			assert.Equal(t, expectedResource, res)
+		})
+	}
+}
@@ -3,6 +3,7 @@ module go.opentelemetry.io/contrib/detectors/aws/lambda
 go 1.20
 
 require (
+	github.com/aws/aws-lambda-go v1.43.0
 	github.com/stretchr/testify v1.8.4
 	go.opentelemetry.io/otel v1.21.0
 	go.opentelemetry.io/otel/sdk v1.21.0
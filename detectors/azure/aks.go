@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azure // import "go.opentelemetry.io/contrib/detectors/azure"
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+)
+
+const (
+	aksClusterNameTag        = "aks-managed-cluster-name"
+	aksNodeResourceGroupPref = "MC_"
+	k8sServiceHostEnvVar     = "KUBERNETES_SERVICE_HOST"
+)
+
+// aksDetector collects resource information from the Azure Kubernetes
+// Service environment.
+type aksDetector struct {
+	c Client
+}
+
+// compile time assertion that aksDetector implements the resource.Detector interface.
+var _ resource.Detector = (*aksDetector)(nil)
+
+// NewAKSResourceDetector returns a resource detector that will detect Azure
+// Kubernetes Service resources from the IMDS of the node the process runs
+// on.
+func NewAKSResourceDetector(opts ...Option) resource.Detector {
+	c := newConfig(opts...)
+	return &aksDetector{c.getClient()}
+}
+
+// Detect detects associated resources when running on AKS. The
+// k8s.cluster.name attribute is detected from the tag AKS sets on its
+// nodes.
+func (detector *aksDetector) Detect(ctx context.Context) (*resource.Resource, error) {
+	if os.Getenv(k8sServiceHostEnvVar) == "" || !detector.c.Available(ctx) {
+		return nil, nil
+	}
+
+	m, err := detector.c.GetComputeMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	clusterName := m.tag(aksClusterNameTag)
+	if clusterName == "" && !hasPrefix(m.ResourceGroupName, aksNodeResourceGroupPref) {
+		// A VM that is not an AKS node.
+		return nil, nil
+	}
+
+	attributes := []attribute.KeyValue{
+		semconv.CloudProviderAzure,
+		semconv.CloudPlatformAzureAKS,
+	}
+	attributes = append(attributes, m.cloudAttributes()...)
+	attributes = appendNonEmpty(attributes, semconv.K8SClusterName(clusterName))
+
+	return resource.NewWithAttributes(semconv.SchemaURL, attributes...), nil
+}
+
+// hasPrefix returns if s has the prefix, ignoring its case, as the node
+// resource group may be lower-cased.
+func hasPrefix(s, prefix string) bool {
+	return len(s) >= len(prefix) && strings.EqualFold(s[:len(prefix)], prefix)
+}
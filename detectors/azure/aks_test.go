@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azure
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+)
+
+func TestAKSDetect(t *testing.T) {
+	t.Setenv(k8sServiceHostEnvVar, "10.0.0.1")
+
+	client := &clientMock{metadata: ComputeMetadata{
+		Location:          "westeurope",
+		ResourceGroupName: "MC_my-group_my-cluster_westeurope",
+		SubscriptionID:    "00000000-0000-0000-0000-000000000000",
+		TagsList:          []Tag{{Name: aksClusterNameTag, Value: "my-cluster"}},
+	}}
+	r, err := NewAKSResourceDetector(WithClient(client)).Detect(context.Background())
+	require.NoError(t, err)
+
+	expected := resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.CloudProviderAzure,
+		semconv.CloudPlatformAzureAKS,
+		semconv.CloudRegion("westeurope"),
+		semconv.CloudAccountID("00000000-0000-0000-0000-000000000000"),
+		resourceGroupNameKey.String("MC_my-group_my-cluster_westeurope"),
+		semconv.K8SClusterName("my-cluster"),
+	)
+	assert.Equal(t, expected, r)
+}
+
+func TestAKSDetectNotAKS(t *testing.T) {
+	vm := &clientMock{metadata: ComputeMetadata{ResourceGroupName: "my-group"}}
+
+	t.Setenv(k8sServiceHostEnvVar, "")
+	r, err := NewAKSResourceDetector(WithClient(vm)).Detect(context.Background())
+	require.NoError(t, err)
+	assert.Nil(t, r, "not in Kubernetes")
+
+	t.Setenv(k8sServiceHostEnvVar, "10.0.0.1")
+	r, err = NewAKSResourceDetector(WithClient(vm)).Detect(context.Background())
+	require.NoError(t, err)
+	assert.Nil(t, r, "not on an AKS node")
+
+	r, err = NewAKSResourceDetector(WithClient(&clientMock{unavailable: true})).Detect(context.Background())
+	require.NoError(t, err)
+	assert.Nil(t, r, "not on Azure")
+}
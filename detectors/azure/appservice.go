@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azure // import "go.opentelemetry.io/contrib/detectors/azure"
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+)
+
+// Environment variables set in App Service and Functions, see
+// https://learn.microsoft.com/en-us/azure/app-service/reference-app-settings.
+const (
+	siteNameEnvVar         = "WEBSITE_SITE_NAME"
+	regionNameEnvVar       = "REGION_NAME"
+	ownerNameEnvVar        = "WEBSITE_OWNER_NAME"
+	resourceGroupEnvVar    = "WEBSITE_RESOURCE_GROUP"
+	instanceIDEnvVar       = "WEBSITE_INSTANCE_ID"
+	hostNameEnvVar         = "WEBSITE_HOSTNAME"
+	slotNameEnvVar         = "WEBSITE_SLOT_NAME"
+	homeStampNameEnvVar    = "WEBSITE_HOME_STAMPNAME"
+	functionsVersionEnvVar = "FUNCTIONS_EXTENSION_VERSION"
+)
+
+// appServiceDetector collects resource information from the Azure App
+// Service environment.
+type appServiceDetector struct{}
+
+// compile time assertion that appServiceDetector implements the resource.Detector interface.
+var _ resource.Detector = (*appServiceDetector)(nil)
+
+// NewAppServiceResourceDetector returns a resource detector that will detect
+// Azure App Service resources from the environment variables of the app.
+// Function apps, which run on App Service, are detected by the detector
+// returned by NewFunctionsResourceDetector instead.
+func NewAppServiceResourceDetector() resource.Detector {
+	return &appServiceDetector{}
+}
+
+// Detect detects associated resources when running on App Service.
+func (detector *appServiceDetector) Detect(context.Context) (*resource.Resource, error) {
+	siteName := os.Getenv(siteNameEnvVar)
+	if siteName == "" || os.Getenv(functionsVersionEnvVar) != "" {
+		return nil, nil
+	}
+
+	attributes := []attribute.KeyValue{
+		semconv.CloudProviderAzure,
+		semconv.CloudPlatformAzureAppService,
+	}
+	attributes = append(attributes, siteAttributes(siteName)...)
+	attributes = appendNonEmpty(attributes,
+		semconv.HostName(os.Getenv(hostNameEnvVar)),
+		semconv.ServiceInstanceID(os.Getenv(instanceIDEnvVar)),
+		appServiceSlotNameKey.String(os.Getenv(slotNameEnvVar)),
+		appServiceStampKey.String(os.Getenv(homeStampNameEnvVar)),
+	)
+
+	return resource.NewWithAttributes(semconv.SchemaURL, attributes...), nil
+}
+
+// siteAttributes returns the region, subscription, resource group and
+// resource ID attributes of the App Service site.
+func siteAttributes(siteName string) []attribute.KeyValue {
+	resourceGroup := os.Getenv(resourceGroupEnvVar)
+	// The owner name is "<subscription id>+<resource group>-<region>webspace".
+	subscriptionID, _, _ := strings.Cut(os.Getenv(ownerNameEnvVar), "+")
+
+	var resourceID string
+	if subscriptionID != "" && resourceGroup != "" {
+		resourceID = fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Web/sites/%s", subscriptionID, resourceGroup, siteName)
+	}
+
+	return appendNonEmpty(nil,
+		semconv.CloudRegion(os.Getenv(regionNameEnvVar)),
+		semconv.CloudAccountID(subscriptionID),
+		resourceGroupNameKey.String(resourceGroup),
+		semconv.CloudResourceID(resourceID),
+	)
+}
@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azure
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+)
+
+func setSiteEnv(t *testing.T) {
+	t.Setenv(siteNameEnvVar, "my-app")
+	t.Setenv(regionNameEnvVar, "West Europe")
+	t.Setenv(ownerNameEnvVar, "00000000-0000-0000-0000-000000000000+my-group-WestEuropewebspace-Linux")
+	t.Setenv(resourceGroupEnvVar, "my-group")
+	t.Setenv(instanceIDEnvVar, "a1b2c3")
+	t.Setenv(hostNameEnvVar, "my-app.azurewebsites.net")
+	t.Setenv(slotNameEnvVar, "production")
+	t.Setenv(homeStampNameEnvVar, "waws-prod-am2-123")
+}
+
+func TestAppServiceDetect(t *testing.T) {
+	setSiteEnv(t)
+	t.Setenv(functionsVersionEnvVar, "")
+
+	r, err := NewAppServiceResourceDetector().Detect(context.Background())
+	require.NoError(t, err)
+
+	expected := resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.CloudProviderAzure,
+		semconv.CloudPlatformAzureAppService,
+		semconv.CloudRegion("West Europe"),
+		semconv.CloudAccountID("00000000-0000-0000-0000-000000000000"),
+		resourceGroupNameKey.String("my-group"),
+		semconv.CloudResourceID("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/my-group/providers/Microsoft.Web/sites/my-app"),
+		semconv.HostName("my-app.azurewebsites.net"),
+		semconv.ServiceInstanceID("a1b2c3"),
+		attribute.String("azure.app_service.slot_name", "production"),
+		attribute.String("azure.app_service.stamp", "waws-prod-am2-123"),
+	)
+	assert.Equal(t, expected, r)
+
+	r, err = NewFunctionsResourceDetector().Detect(context.Background())
+	require.NoError(t, err)
+	assert.Nil(t, r)
+}
+
+func TestFunctionsDetect(t *testing.T) {
+	setSiteEnv(t)
+	t.Setenv(functionsVersionEnvVar, "~4")
+	t.Setenv(memoryLimitEnvVar, "1536")
+
+	r, err := NewFunctionsResourceDetector().Detect(context.Background())
+	require.NoError(t, err)
+
+	expected := resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.CloudProviderAzure,
+		semconv.CloudPlatformAzureFunctions,
+		semconv.FaaSName("my-app"),
+		semconv.CloudRegion("West Europe"),
+		semconv.CloudAccountID("00000000-0000-0000-0000-000000000000"),
+		resourceGroupNameKey.String("my-group"),
+		semconv.CloudResourceID("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/my-group/providers/Microsoft.Web/sites/my-app"),
+		semconv.FaaSInstance("a1b2c3"),
+		semconv.FaaSMaxMemory(1536*1024*1024),
+	)
+	assert.Equal(t, expected, r)
+
+	r, err = NewAppServiceResourceDetector().Detect(context.Background())
+	require.NoError(t, err)
+	assert.Nil(t, r)
+}
+
+func TestSiteNotDetected(t *testing.T) {
+	t.Setenv(siteNameEnvVar, "")
+
+	r, err := NewAppServiceResourceDetector().Detect(context.Background())
+	require.NoError(t, err)
+	assert.Nil(t, r)
+
+	r, err = NewFunctionsResourceDetector().Detect(context.Background())
+	require.NoError(t, err)
+	assert.Nil(t, r)
+}
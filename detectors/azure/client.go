@@ -0,0 +1,189 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package azure provides resource detectors for Azure Virtual Machines, App
+// Service, Functions and Azure Kubernetes Service (AKS).
+package azure // import "go.opentelemetry.io/contrib/detectors/azure"
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	defaultEndpoint   = "http://169.254.169.254"
+	computePath       = "/metadata/instance/compute?api-version=2021-12-13&format=json"
+	versionsPath      = "/metadata/versions"
+	defaultTimeout    = 2 * time.Second
+	metadataHeader    = "Metadata"
+	maxMetadataLength = 1 << 20
+)
+
+// Resource attributes of Azure that are not defined by the semantic
+// conventions.
+const (
+	resourceGroupNameKey  = attribute.Key("azure.resourcegroup.name")
+	vmScaleSetNameKey     = attribute.Key("azure.vm.scaleset.name")
+	vmSKUKey              = attribute.Key("azure.vm.sku")
+	appServiceStampKey    = attribute.Key("azure.app_service.stamp")
+	appServiceSlotNameKey = attribute.Key("azure.app_service.slot_name")
+)
+
+// Client implements methods to capture Azure VM environment metadata
+// information from the Azure Instance Metadata Service (IMDS).
+type Client interface {
+	Available(ctx context.Context) bool
+	GetComputeMetadata(ctx context.Context) (ComputeMetadata, error)
+}
+
+// ComputeMetadata is the compute metadata of an Azure VM, returned by the
+// /metadata/instance/compute endpoint of the IMDS.
+type ComputeMetadata struct {
+	Location          string `json:"location"`
+	Name              string `json:"name"`
+	OSType            string `json:"osType"`
+	ResourceGroupName string `json:"resourceGroupName"`
+	ResourceID        string `json:"resourceId"`
+	SKU               string `json:"sku"`
+	SubscriptionID    string `json:"subscriptionId"`
+	TagsList          []Tag  `json:"tagsList"`
+	Version           string `json:"version"`
+	VMID              string `json:"vmId"`
+	VMScaleSetName    string `json:"vmScaleSetName"`
+	VMSize            string `json:"vmSize"`
+	Zone              string `json:"zone"`
+}
+
+// Tag is a tag of an Azure resource.
+type Tag struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// tag returns the value of the tag with name, or an empty string.
+func (m ComputeMetadata) tag(name string) string {
+	for _, t := range m.TagsList {
+		if t.Name == name {
+			return t.Value
+		}
+	}
+	return ""
+}
+
+type config struct {
+	c        Client
+	endpoint string
+}
+
+// newConfig returns an appropriately configured config.
+func newConfig(options ...Option) *config {
+	c := &config{endpoint: defaultEndpoint}
+	for _, option := range options {
+		option.apply(c)
+	}
+
+	return c
+}
+
+// Option applies an Azure detector configuration option.
+type Option interface {
+	apply(*config)
+}
+
+type optionFunc func(*config)
+
+func (fn optionFunc) apply(c *config) {
+	fn(c)
+}
+
+// WithClient sets the IMDS client in config.
+func WithClient(t Client) Option {
+	return optionFunc(func(c *config) {
+		c.c = t
+	})
+}
+
+// WithEndpoint sets the base URL of the IMDS used when no client is set with
+// WithClient. It defaults to http://169.254.169.254.
+func WithEndpoint(endpoint string) Option {
+	return optionFunc(func(c *config) {
+		c.endpoint = endpoint
+	})
+}
+
+func (cfg *config) getClient() Client {
+	if cfg.c != nil {
+		return cfg.c
+	}
+	return &imdsClient{
+		endpoint: cfg.endpoint,
+		client: &http.Client{
+			// The IMDS must not be reached through a proxy.
+			Transport: &http.Transport{Proxy: nil},
+			Timeout:   defaultTimeout,
+		},
+	}
+}
+
+// imdsClient is the Client requesting the IMDS over HTTP.
+type imdsClient struct {
+	endpoint string
+	client   *http.Client
+}
+
+// compile time assertion that imdsClient implements the Client interface.
+var _ Client = (*imdsClient)(nil)
+
+// Available returns if the IMDS is reachable.
+func (c *imdsClient) Available(ctx context.Context) bool {
+	resp, err := c.get(ctx, versionsPath)
+	if err != nil {
+		return false
+	}
+	_ = resp.Body.Close()
+	return resp.StatusCode == http.StatusOK
+}
+
+// GetComputeMetadata returns the compute metadata of the VM.
+func (c *imdsClient) GetComputeMetadata(ctx context.Context) (ComputeMetadata, error) {
+	var m ComputeMetadata
+	resp, err := c.get(ctx, computePath)
+	if err != nil {
+		return m, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return m, fmt.Errorf("failed to get compute metadata: %s", resp.Status)
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxMetadataLength)).Decode(&m); err != nil {
+		return m, fmt.Errorf("failed to decode compute metadata: %w", err)
+	}
+	return m, nil
+}
+
+func (c *imdsClient) get(ctx context.Context, path string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.endpoint+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set(metadataHeader, "true")
+	return c.client.Do(req)
+}
@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azure // import "go.opentelemetry.io/contrib/detectors/azure"
+
+import (
+	"context"
+	"os"
+	"strconv"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+)
+
+const memoryLimitEnvVar = "WEBSITE_MEMORY_LIMIT_MB"
+
+// functionsDetector collects resource information from the Azure Functions
+// environment.
+type functionsDetector struct{}
+
+// compile time assertion that functionsDetector implements the resource.Detector interface.
+var _ resource.Detector = (*functionsDetector)(nil)
+
+// NewFunctionsResourceDetector returns a resource detector that will detect
+// Azure Functions resources from the environment variables of the function
+// app.
+func NewFunctionsResourceDetector() resource.Detector {
+	return &functionsDetector{}
+}
+
+// Detect detects associated resources when running on Azure Functions.
+func (detector *functionsDetector) Detect(context.Context) (*resource.Resource, error) {
+	siteName := os.Getenv(siteNameEnvVar)
+	if siteName == "" || os.Getenv(functionsVersionEnvVar) == "" {
+		return nil, nil
+	}
+
+	attributes := []attribute.KeyValue{
+		semconv.CloudProviderAzure,
+		semconv.CloudPlatformAzureFunctions,
+		semconv.FaaSName(siteName),
+	}
+	attributes = append(attributes, siteAttributes(siteName)...)
+	attributes = appendNonEmpty(attributes,
+		semconv.FaaSInstance(os.Getenv(instanceIDEnvVar)),
+	)
+	if limit, err := strconv.Atoi(os.Getenv(memoryLimitEnvVar)); err == nil {
+		attributes = append(attributes, semconv.FaaSMaxMemory(limit*1024*1024))
+	}
+
+	return resource.NewWithAttributes(semconv.SchemaURL, attributes...), nil
+}
@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azure // import "go.opentelemetry.io/contrib/detectors/azure"
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+)
+
+// vmDetector collects resource information from the Azure VM environment.
+type vmDetector struct {
+	c Client
+}
+
+// compile time assertion that vmDetector implements the resource.Detector interface.
+var _ resource.Detector = (*vmDetector)(nil)
+
+// NewVMResourceDetector returns a resource detector that will detect Azure
+// Virtual Machine resources from the IMDS.
+func NewVMResourceDetector(opts ...Option) resource.Detector {
+	c := newConfig(opts...)
+	return &vmDetector{c.getClient()}
+}
+
+// Detect detects associated resources when running on an Azure VM.
+func (detector *vmDetector) Detect(ctx context.Context) (*resource.Resource, error) {
+	if !detector.c.Available(ctx) {
+		return nil, nil
+	}
+
+	m, err := detector.c.GetComputeMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	attributes := []attribute.KeyValue{
+		semconv.CloudProviderAzure,
+		semconv.CloudPlatformAzureVM,
+	}
+	attributes = append(attributes, m.cloudAttributes()...)
+	attributes = appendNonEmpty(attributes,
+		semconv.CloudResourceID(m.ResourceID),
+		semconv.CloudAvailabilityZone(m.Zone),
+		semconv.HostID(m.VMID),
+		semconv.HostName(m.Name),
+		semconv.HostType(m.VMSize),
+		semconv.OSTypeKey.String(strings.ToLower(m.OSType)),
+		semconv.OSVersion(m.Version),
+		vmScaleSetNameKey.String(m.VMScaleSetName),
+		vmSKUKey.String(m.SKU),
+	)
+
+	return resource.NewWithAttributes(semconv.SchemaURL, attributes...), nil
+}
+
+// cloudAttributes returns the attributes of the region, subscription and
+// resource group of the VM.
+func (m ComputeMetadata) cloudAttributes() []attribute.KeyValue {
+	return appendNonEmpty(nil,
+		semconv.CloudRegion(m.Location),
+		semconv.CloudAccountID(m.SubscriptionID),
+		resourceGroupNameKey.String(m.ResourceGroupName),
+	)
+}
+
+// appendNonEmpty appends the attributes of kvs with a non-empty value to
+// attributes.
+func appendNonEmpty(attributes []attribute.KeyValue, kvs ...attribute.KeyValue) []attribute.KeyValue {
+	for _, kv := range kvs {
+		if kv.Value.AsString() != "" {
+			attributes = append(attributes, kv)
+		}
+	}
+	return attributes
+}
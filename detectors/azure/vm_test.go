@@ -0,0 +1,127 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azure
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+)
+
+const computeJSON = `{
+	"location": "westeurope",
+	"name": "my-vm",
+	"osType": "Linux",
+	"resourceGroupName": "my-group",
+	"resourceId": "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/my-group/providers/Microsoft.Compute/virtualMachines/my-vm",
+	"sku": "22_04-lts-gen2",
+	"subscriptionId": "00000000-0000-0000-0000-000000000000",
+	"tagsList": [{"name": "team", "value": "platform"}],
+	"version": "22.04.202311010",
+	"vmId": "02aab8a4-74ef-476e-8182-f6d2ba4166a6",
+	"vmScaleSetName": "",
+	"vmSize": "Standard_D2s_v3",
+	"zone": "1"
+}`
+
+// newIMDSServer returns a fake IMDS serving the compute metadata.
+func newIMDSServer(t *testing.T, compute string) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metadata/versions", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"apiVersions":["2021-12-13"]}`))
+	})
+	mux.HandleFunc("/metadata/instance/compute", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "2021-12-13", r.URL.Query().Get("api-version"))
+		_, _ = w.Write([]byte(compute))
+	})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(metadataHeader) != "true" {
+			http.Error(w, "missing Metadata header", http.StatusBadRequest)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestVMDetect(t *testing.T) {
+	srv := newIMDSServer(t, computeJSON)
+
+	r, err := NewVMResourceDetector(WithEndpoint(srv.URL)).Detect(context.Background())
+	require.NoError(t, err)
+
+	expected := resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.CloudProviderAzure,
+		semconv.CloudPlatformAzureVM,
+		semconv.CloudRegion("westeurope"),
+		semconv.CloudAccountID("00000000-0000-0000-0000-000000000000"),
+		resourceGroupNameKey.String("my-group"),
+		semconv.CloudResourceID("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/my-group/providers/Microsoft.Compute/virtualMachines/my-vm"),
+		semconv.CloudAvailabilityZone("1"),
+		semconv.HostID("02aab8a4-74ef-476e-8182-f6d2ba4166a6"),
+		semconv.HostName("my-vm"),
+		semconv.HostType("Standard_D2s_v3"),
+		semconv.OSTypeLinux,
+		semconv.OSVersion("22.04.202311010"),
+		vmSKUKey.String("22_04-lts-gen2"),
+	)
+	assert.Equal(t, expected, r)
+}
+
+func TestVMDetectNotAvailable(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+
+	r, err := NewVMResourceDetector(WithEndpoint(srv.URL)).Detect(context.Background())
+	require.NoError(t, err)
+	assert.Nil(t, r)
+}
+
+func TestVMDetectInvalidMetadata(t *testing.T) {
+	srv := newIMDSServer(t, "{")
+
+	_, err := NewVMResourceDetector(WithEndpoint(srv.URL)).Detect(context.Background())
+	assert.ErrorContains(t, err, "failed to decode compute metadata")
+}
+
+func TestVMDetectClientError(t *testing.T) {
+	client := &clientMock{err: errors.New("boom")}
+
+	_, err := NewVMResourceDetector(WithClient(client)).Detect(context.Background())
+	assert.EqualError(t, err, "boom")
+}
+
+type clientMock struct {
+	unavailable bool
+	metadata    ComputeMetadata
+	err         error
+}
+
+func (c *clientMock) Available(context.Context) bool {
+	return !c.unavailable
+}
+
+func (c *clientMock) GetComputeMetadata(context.Context) (ComputeMetadata, error) {
+	return c.metadata, c.err
+}
@@ -0,0 +1,186 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cache provides a resource detector wrapping another one to run its
+// detection in the background, bound its duration with a deadline, and
+// optionally reuse the detected resource across restarts from a local cache
+// file.
+//
+// It is meant for the detectors calling metadata endpoints over the network,
+// e.g. the EC2, ECS and GCP detectors, which slow down the start of
+// processes and tests.
+package cache // import "go.opentelemetry.io/contrib/detectors/cache"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+const defaultTimeout = 5 * time.Second
+
+type config struct {
+	timeout time.Duration
+	path    string
+	ttl     time.Duration
+}
+
+// newConfig returns an appropriately configured config.
+func newConfig(options ...Option) *config {
+	c := &config{timeout: defaultTimeout}
+	for _, option := range options {
+		option.apply(c)
+	}
+
+	return c
+}
+
+// Option applies a cached detector configuration option.
+type Option interface {
+	apply(*config)
+}
+
+type optionFunc func(*config)
+
+func (fn optionFunc) apply(c *config) {
+	fn(c)
+}
+
+// WithTimeout sets the deadline of the detection, from the creation of the
+// detector. Detect returns the resource that is available when it expires,
+// while the detection continues in the background. It defaults to 5
+// seconds, a zero or negative timeout disables it.
+func WithTimeout(timeout time.Duration) Option {
+	return optionFunc(func(c *config) {
+		c.timeout = timeout
+	})
+}
+
+// WithCacheFile persists the detected resource to the file at path. A
+// resource persisted less than ttl ago is reused instead of running the
+// detection, e.g. on the next start of the process.
+func WithCacheFile(path string, ttl time.Duration) Option {
+	return optionFunc(func(c *config) {
+		c.path = path
+		c.ttl = ttl
+	})
+}
+
+// detector runs the detection of a wrapped detector once, in the
+// background.
+type detector struct {
+	// deadline is the deadline of the detection, or the zero time if it has
+	// none.
+	deadline time.Time
+	// cached is the resource read from the cache file, or nil.
+	cached *resource.Resource
+
+	done chan struct{}
+	mu   sync.Mutex
+	res  *resource.Resource
+	err  error
+}
+
+// compile time assertion that detector implements the resource.Detector interface.
+var _ resource.Detector = (*detector)(nil)
+
+// NewDetector returns a resource detector running the detection of d in the
+// background, starting immediately. The detection runs once, and its result
+// is returned by every call of Detect.
+//
+// If the detection does not complete before the deadline set with
+// WithTimeout, or before the context passed to Detect is done, Detect
+// returns the resource previously persisted to the cache file, if any, or
+// an empty resource, with an error wrapping resource.ErrPartialResource.
+// Combined with the timeout of a detector composing several detectors, e.g.
+// the autodetect package, the resources of the detectors that completed in
+// time are returned.
+func NewDetector(d resource.Detector, opts ...Option) resource.Detector {
+	return newDetector(d, time.Now, opts...)
+}
+
+// newDetector returns the detector, using now as the clock of the cache
+// file.
+func newDetector(d resource.Detector, now func() time.Time, opts ...Option) *detector {
+	c := newConfig(opts...)
+	det := &detector{done: make(chan struct{})}
+	if c.timeout > 0 {
+		det.deadline = time.Now().Add(c.timeout)
+	}
+
+	var f *cacheFile
+	if c.path != "" {
+		f = &cacheFile{path: c.path, ttl: c.ttl, now: now}
+		cached, fresh, err := f.read()
+		if err != nil {
+			otel.Handle(err)
+		}
+		det.cached = cached
+		if fresh {
+			det.complete(cached, nil)
+			return det
+		}
+	}
+
+	go func() {
+		res, err := d.Detect(context.Background())
+		if err == nil && f != nil {
+			if err := f.write(res); err != nil {
+				otel.Handle(err)
+			}
+		}
+		det.complete(res, err)
+	}()
+	return det
+}
+
+func (d *detector) complete(res *resource.Resource, err error) {
+	d.mu.Lock()
+	d.res, d.err = res, err
+	d.mu.Unlock()
+	close(d.done)
+}
+
+// Detect returns the resource detected in the background, waiting for it
+// until the deadline, if any, or ctx is done.
+func (d *detector) Detect(ctx context.Context) (*resource.Resource, error) {
+	if !d.deadline.IsZero() {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, d.deadline)
+		defer cancel()
+	}
+
+	select {
+	case <-d.done:
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		return d.res, d.err
+	case <-ctx.Done():
+	}
+
+	res := d.cached
+	if res == nil {
+		res = resource.Empty()
+	}
+	return res, fmt.Errorf("%w: %w", resource.ErrPartialResource, errors.Join(errDetectionPending, ctx.Err()))
+}
+
+// errDetectionPending is returned when the detection did not complete in
+// time.
+var errDetectionPending = errors.New("resource detection did not complete")
@@ -0,0 +1,184 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+)
+
+// blockingDetector detects res once release is closed.
+type blockingDetector struct {
+	release chan struct{}
+	calls   atomic.Int32
+	res     *resource.Resource
+	err     error
+}
+
+func newBlockingDetector(res *resource.Resource, err error) *blockingDetector {
+	return &blockingDetector{release: make(chan struct{}), res: res, err: err}
+}
+
+func (d *blockingDetector) Detect(context.Context) (*resource.Resource, error) {
+	d.calls.Add(1)
+	<-d.release
+	return d.res, d.err
+}
+
+var detected = resource.NewWithAttributes(semconv.SchemaURL,
+	semconv.CloudProviderAWS,
+	semconv.HostID("i-1234567890abcdef0"),
+)
+
+func TestDetect(t *testing.T) {
+	d := newBlockingDetector(detected, nil)
+	close(d.release)
+
+	r, err := NewDetector(d).Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, detected, r)
+}
+
+func TestDetectError(t *testing.T) {
+	errDetect := errors.New("detection failed")
+	d := newBlockingDetector(nil, errDetect)
+	close(d.release)
+
+	_, err := NewDetector(d).Detect(context.Background())
+	assert.ErrorIs(t, err, errDetect)
+}
+
+func TestDetectTimeout(t *testing.T) {
+	d := newBlockingDetector(detected, nil)
+	det := NewDetector(d, WithTimeout(10*time.Millisecond))
+
+	r, err := det.Detect(context.Background())
+	assert.ErrorIs(t, err, resource.ErrPartialResource)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, resource.Empty(), r)
+
+	// The detection continues in the background.
+	close(d.release)
+	require.Eventually(t, func() bool {
+		r, err := det.Detect(context.Background())
+		return err == nil && r == detected
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, int32(1), d.calls.Load())
+}
+
+func TestDetectNoTimeout(t *testing.T) {
+	for _, timeout := range []time.Duration{0, -time.Second} {
+		d := newBlockingDetector(detected, nil)
+		det := NewDetector(d, WithTimeout(timeout))
+
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			close(d.release)
+		}()
+		r, err := det.Detect(context.Background())
+		require.NoError(t, err, timeout)
+		assert.Equal(t, detected, r, timeout)
+	}
+}
+
+func TestDetectContextDone(t *testing.T) {
+	d := newBlockingDetector(detected, nil)
+	t.Cleanup(func() { close(d.release) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := NewDetector(d).Detect(ctx)
+	assert.ErrorIs(t, err, resource.ErrPartialResource)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestDetectCacheFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "resource.json")
+	now := time.Date(2023, time.December, 1, 12, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+
+	// The first start detects the resource and persists it.
+	d := newBlockingDetector(detected, nil)
+	close(d.release)
+	r, err := newDetector(d, clock, WithCacheFile(path, time.Hour)).Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, detected, r)
+	require.FileExists(t, path)
+
+	// The next start within the TTL reuses it.
+	d = newBlockingDetector(nil, nil)
+	now = now.Add(59 * time.Minute)
+	r, err = newDetector(d, clock, WithCacheFile(path, time.Hour)).Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, detected, r)
+	assert.Equal(t, int32(0), d.calls.Load())
+
+	// After the TTL, the resource is detected again, and the stale cached
+	// resource is only returned if the detection does not complete in time.
+	now = now.Add(time.Minute)
+	d = newBlockingDetector(nil, errors.New("not persisted"))
+	t.Cleanup(func() { close(d.release) })
+	r, err = newDetector(d, clock, WithCacheFile(path, time.Hour), WithTimeout(10*time.Millisecond)).Detect(context.Background())
+	assert.ErrorIs(t, err, resource.ErrPartialResource)
+	assert.Equal(t, detected, r)
+	require.Eventually(t, func() bool { return d.calls.Load() == 1 }, time.Second, time.Millisecond)
+}
+
+func TestCacheFileRoundTrip(t *testing.T) {
+	f := &cacheFile{path: filepath.Join(t.TempDir(), "resource.json"), ttl: time.Hour, now: time.Now}
+	res := resource.NewWithAttributes(semconv.SchemaURL,
+		attribute.Bool("bool", true),
+		attribute.Int64("int", 42),
+		attribute.Float64("float", 1.5),
+		attribute.String("string", "value"),
+		attribute.BoolSlice("bools", []bool{true, false}),
+		attribute.Int64Slice("ints", []int64{1, 2}),
+		attribute.Float64Slice("floats", []float64{1.5, 2.5}),
+		attribute.StringSlice("strings", []string{"a", "b"}),
+	)
+	require.NoError(t, f.write(res))
+
+	got, fresh, err := f.read()
+	require.NoError(t, err)
+	assert.True(t, fresh)
+	assert.Equal(t, res, got)
+}
+
+func TestCacheFileInvalid(t *testing.T) {
+	dir := t.TempDir()
+
+	f := &cacheFile{path: filepath.Join(dir, "missing.json"), now: time.Now}
+	r, fresh, err := f.read()
+	require.NoError(t, err)
+	assert.False(t, fresh)
+	assert.Nil(t, r)
+
+	f.path = filepath.Join(dir, "invalid.json")
+	require.NoError(t, os.WriteFile(f.path, []byte(`{"attributes":[{"key":"k","type":"MAP","value":{}}]}`), 0o600))
+	_, _, err = f.read()
+	assert.ErrorContains(t, err, `attribute "k": unsupported type MAP`)
+}
@@ -0,0 +1,183 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache // import "go.opentelemetry.io/contrib/detectors/cache"
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// cacheFile persists a detected resource.
+type cacheFile struct {
+	path string
+	ttl  time.Duration
+	now  func() time.Time
+}
+
+// cachedResource is the JSON representation of a persisted resource.
+type cachedResource struct {
+	DetectedAt time.Time         `json:"detected_at"`
+	SchemaURL  string            `json:"schema_url,omitempty"`
+	Attributes []cachedAttribute `json:"attributes"`
+}
+
+// cachedAttribute is the JSON representation of a resource attribute, typed
+// to preserve the type of its value.
+type cachedAttribute struct {
+	Key   string          `json:"key"`
+	Type  string          `json:"type"`
+	Value json.RawMessage `json:"value"`
+}
+
+// read returns the resource of the cache file, and if it was detected less
+// than the TTL ago. A nil resource and no error are returned if the file
+// does not exist.
+func (f *cacheFile) read() (*resource.Resource, bool, error) {
+	data, err := os.ReadFile(f.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read resource cache: %w", err)
+	}
+
+	var c cachedResource
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, false, fmt.Errorf("failed to decode resource cache %s: %w", f.path, err)
+	}
+	attrs := make([]attribute.KeyValue, 0, len(c.Attributes))
+	for _, a := range c.Attributes {
+		kv, err := a.keyValue()
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to decode resource cache %s: %w", f.path, err)
+		}
+		attrs = append(attrs, kv)
+	}
+
+	fresh := f.now().Sub(c.DetectedAt) < f.ttl
+	return resource.NewWithAttributes(c.SchemaURL, attrs...), fresh, nil
+}
+
+// write persists res to the cache file. The file is replaced atomically so
+// concurrent processes never read a partial file.
+func (f *cacheFile) write(res *resource.Resource) error {
+	c := cachedResource{DetectedAt: f.now(), SchemaURL: res.SchemaURL()}
+	for _, kv := range res.Attributes() {
+		a, err := newCachedAttribute(kv)
+		if err != nil {
+			return fmt.Errorf("failed to encode resource cache: %w", err)
+		}
+		c.Attributes = append(c.Attributes, a)
+	}
+	data, err := json.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("failed to encode resource cache: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(f.path), filepath.Base(f.path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to write resource cache: %w", err)
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), f.path)
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write resource cache: %w", err)
+	}
+	return nil
+}
+
+func newCachedAttribute(kv attribute.KeyValue) (cachedAttribute, error) {
+	var v interface{}
+	switch kv.Value.Type() {
+	case attribute.BOOL:
+		v = kv.Value.AsBool()
+	case attribute.INT64:
+		v = kv.Value.AsInt64()
+	case attribute.FLOAT64:
+		v = kv.Value.AsFloat64()
+	case attribute.STRING:
+		v = kv.Value.AsString()
+	case attribute.BOOLSLICE:
+		v = kv.Value.AsBoolSlice()
+	case attribute.INT64SLICE:
+		v = kv.Value.AsInt64Slice()
+	case attribute.FLOAT64SLICE:
+		v = kv.Value.AsFloat64Slice()
+	case attribute.STRINGSLICE:
+		v = kv.Value.AsStringSlice()
+	default:
+		return cachedAttribute{}, fmt.Errorf("attribute %q: unsupported type %s", kv.Key, kv.Value.Type())
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return cachedAttribute{}, err
+	}
+	return cachedAttribute{Key: string(kv.Key), Type: kv.Value.Type().String(), Value: data}, nil
+}
+
+func (a cachedAttribute) keyValue() (attribute.KeyValue, error) {
+	k := attribute.Key(a.Key)
+	var err error
+	switch a.Type {
+	case attribute.BOOL.String():
+		var v bool
+		err = json.Unmarshal(a.Value, &v)
+		return k.Bool(v), err
+	case attribute.INT64.String():
+		var v int64
+		err = json.Unmarshal(a.Value, &v)
+		return k.Int64(v), err
+	case attribute.FLOAT64.String():
+		var v float64
+		err = json.Unmarshal(a.Value, &v)
+		return k.Float64(v), err
+	case attribute.STRING.String():
+		var v string
+		err = json.Unmarshal(a.Value, &v)
+		return k.String(v), err
+	case attribute.BOOLSLICE.String():
+		var v []bool
+		err = json.Unmarshal(a.Value, &v)
+		return k.BoolSlice(v), err
+	case attribute.INT64SLICE.String():
+		var v []int64
+		err = json.Unmarshal(a.Value, &v)
+		return k.Int64Slice(v), err
+	case attribute.FLOAT64SLICE.String():
+		var v []float64
+		err = json.Unmarshal(a.Value, &v)
+		return k.Float64Slice(v), err
+	case attribute.STRINGSLICE.String():
+		var v []string
+		err = json.Unmarshal(a.Value, &v)
+		return k.StringSlice(v), err
+	default:
+		return attribute.KeyValue{}, fmt.Errorf("attribute %q: unsupported type %s", a.Key, a.Type)
+	}
+}
@@ -17,6 +17,7 @@ package gcp // import "go.opentelemetry.io/contrib/detectors/gcp"
 import (
 	"context"
 	"fmt"
+	"strconv"
 
 	"cloud.google.com/go/compute/metadata"
 	"github.com/GoogleCloudPlatform/opentelemetry-operations-go/detectors/gcp"
@@ -33,7 +34,7 @@ import (
 // * Cloud Run.
 // * Cloud Functions.
 func NewDetector() resource.Detector {
-	return &detector{detector: gcp.NewDetector()}
+	return &detector{detector: &extendedDetector{Detector: gcp.NewDetector()}}
 }
 
 type detector struct {
@@ -42,6 +43,12 @@ type detector struct {
 
 // Detect detects associated resources when running on GCE, GKE, GAE,
 // Cloud Run, and Cloud functions.
+//
+// On GKE, the k8s.namespace.name, k8s.pod.name and k8s.container.name
+// attributes are detected from the K8S_NAMESPACE_NAME, POD_NAMESPACE or
+// NAMESPACE_NAME, K8S_POD_NAME or POD_NAME, and K8S_CONTAINER_NAME or
+// CONTAINER_NAME environment variables, set in the pod spec with the
+// downward API.
 func (d *detector) Detect(ctx context.Context) (*resource.Resource, error) {
 	if !metadata.OnGCE() {
 		return nil, nil
@@ -56,12 +63,22 @@ func (d *detector) Detect(ctx context.Context) (*resource.Resource, error) {
 		b.addZoneOrRegion(d.detector.GKEAvailabilityZoneOrRegion)
 		b.add(semconv.K8SClusterNameKey, d.detector.GKEClusterName)
 		b.add(semconv.HostIDKey, d.detector.GKEHostID)
+		b.addIfNotEmpty(semconv.K8SNamespaceNameKey, d.detector.GKENamespaceName)
+		b.addIfNotEmpty(semconv.K8SPodNameKey, d.detector.GKEPodName)
+		b.addIfNotEmpty(semconv.K8SContainerNameKey, d.detector.GKEContainerName)
 	case gcp.CloudRun:
 		b.attrs = append(b.attrs, semconv.CloudPlatformGCPCloudRun)
 		b.add(semconv.FaaSNameKey, d.detector.FaaSName)
 		b.add(semconv.FaaSVersionKey, d.detector.FaaSVersion)
 		b.add(semconv.FaaSInstanceKey, d.detector.FaaSID)
 		b.add(semconv.CloudRegionKey, d.detector.FaaSCloudRegion)
+	case gcp.CloudRunJob:
+		b.attrs = append(b.attrs, semconv.CloudPlatformGCPCloudRun)
+		b.add(semconv.FaaSNameKey, d.detector.FaaSName)
+		b.add(semconv.FaaSInstanceKey, d.detector.FaaSID)
+		b.add(semconv.CloudRegionKey, d.detector.FaaSCloudRegion)
+		b.add(cloudRunJobExecutionKey, d.detector.CloudRunJobExecution)
+		b.addInt(cloudRunJobTaskIndexKey, d.detector.CloudRunJobTaskIndex)
 	case gcp.CloudFunctions:
 		b.attrs = append(b.attrs, semconv.CloudPlatformGCPCloudFunctions)
 		b.add(semconv.FaaSNameKey, d.detector.FaaSName)
@@ -89,6 +106,7 @@ func (d *detector) Detect(ctx context.Context) (*resource.Resource, error) {
 		b.add(semconv.HostNameKey, d.detector.GCEHostName)
 		b.add(semconv.GCPGceInstanceNameKey, d.detector.GCEInstanceName)
 		b.add(semconv.GCPGceInstanceHostnameKey, d.detector.GCEInstanceHostname)
+		b.addInstanceGroupManager(d.detector.GCEInstanceGroupManager)
 	default:
 		// We don't support this platform yet, so just return with what we have
 	}
@@ -110,6 +128,47 @@ func (r *resourceBuilder) add(key attribute.Key, detect func() (string, error))
 	}
 }
 
+// addIfNotEmpty adds the attribute only if its value is not empty.
+func (r *resourceBuilder) addIfNotEmpty(key attribute.Key, detect func() (string, error)) {
+	if v, err := detect(); err != nil {
+		r.errs = append(r.errs, err)
+	} else if v != "" {
+		r.attrs = append(r.attrs, key.String(v))
+	}
+}
+
+func (r *resourceBuilder) addInt(key attribute.Key, detect func() (string, error)) {
+	v, err := detect()
+	if err != nil {
+		r.errs = append(r.errs, err)
+		return
+	}
+	if i, err := strconv.Atoi(v); err == nil {
+		r.attrs = append(r.attrs, key.Int(i))
+	} else {
+		r.errs = append(r.errs, err)
+	}
+}
+
+func (r *resourceBuilder) addInstanceGroupManager(detect func() (instanceGroupManager, error)) {
+	mig, err := detect()
+	if err != nil {
+		r.errs = append(r.errs, err)
+		return
+	}
+	if mig.Name == "" {
+		// The instance is not part of a managed instance group.
+		return
+	}
+	r.attrs = append(r.attrs, gceInstanceGroupManagerNameKey.String(mig.Name))
+	switch mig.Type {
+	case gcp.Zone:
+		r.attrs = append(r.attrs, gceInstanceGroupManagerZoneKey.String(mig.Location))
+	case gcp.Region:
+		r.attrs = append(r.attrs, gceInstanceGroupManagerRegionKey.String(mig.Location))
+	}
+}
+
 // zoneAndRegion functions are expected to return zone, region, err.
 func (r *resourceBuilder) addZoneAndRegion(detect func() (string, string, error)) {
 	if zone, region, err := detect(); err == nil {
@@ -54,6 +54,30 @@ func TestDetect(t *testing.T) {
 				semconv.HostID("1472385723456792345"),
 			),
 		},
+		{
+			desc: "GKE pod",
+			detector: &detector{detector: &fakeGCPDetector{
+				projectID:           "my-project",
+				cloudPlatform:       gcp.GKE,
+				gkeHostID:           "1472385723456792345",
+				gkeClusterName:      "my-cluster",
+				gkeAvailabilityZone: "us-central1-c",
+				gkeNamespaceName:    "my-namespace",
+				gkePodName:          "my-pod-5d4f8b-x2x9z",
+				gkeContainerName:    "my-container",
+			}},
+			expectedResource: resource.NewWithAttributes(semconv.SchemaURL,
+				semconv.CloudProviderGCP,
+				semconv.CloudAccountID("my-project"),
+				semconv.CloudPlatformGCPKubernetesEngine,
+				semconv.K8SClusterName("my-cluster"),
+				semconv.CloudAvailabilityZone("us-central1-c"),
+				semconv.HostID("1472385723456792345"),
+				semconv.K8SNamespaceName("my-namespace"),
+				semconv.K8SPodName("my-pod-5d4f8b-x2x9z"),
+				semconv.K8SContainerName("my-container"),
+			),
+		},
 		{
 			desc: "regional GKE cluster",
 			detector: &detector{detector: &fakeGCPDetector{
@@ -118,6 +142,61 @@ func TestDetect(t *testing.T) {
 				semconv.FaaSInstance("1472385723456792345"),
 			),
 		},
+		{
+			desc: "Cloud Run Job",
+			detector: &detector{detector: &fakeGCPDetector{
+				projectID:            "my-project",
+				cloudPlatform:        gcp.CloudRunJob,
+				faaSID:               "1472385723456792345",
+				faaSCloudRegion:      "us-central1",
+				faaSName:             "my-job",
+				cloudRunJobExecution: "my-job-abc12",
+				cloudRunJobTaskIndex: "3",
+			}},
+			expectedResource: resource.NewWithAttributes(semconv.SchemaURL,
+				semconv.CloudProviderGCP,
+				semconv.CloudAccountID("my-project"),
+				semconv.CloudPlatformGCPCloudRun,
+				semconv.CloudRegion("us-central1"),
+				semconv.FaaSName("my-job"),
+				semconv.FaaSInstance("1472385723456792345"),
+				cloudRunJobExecutionKey.String("my-job-abc12"),
+				cloudRunJobTaskIndexKey.Int(3),
+			),
+		},
+		{
+			desc: "GCE in a managed instance group",
+			detector: &detector{detector: &fakeGCPDetector{
+				projectID:              "my-project",
+				cloudPlatform:          gcp.GCE,
+				gceHostID:              "1472385723456792345",
+				gceHostName:            "my-mig-1234",
+				gceHostType:            "n1-standard1",
+				gceAvailabilityZone:    "us-central1-c",
+				gceRegion:              "us-central1",
+				gcpGceInstanceName:     "my-mig-1234",
+				gcpGceInstanceHostname: "hostname",
+				gceInstanceGroupManager: instanceGroupManager{
+					Name:     "my-mig",
+					Location: "us-central1",
+					Type:     gcp.Region,
+				},
+			}},
+			expectedResource: resource.NewWithAttributes(semconv.SchemaURL,
+				semconv.CloudProviderGCP,
+				semconv.CloudAccountID("my-project"),
+				semconv.CloudPlatformGCPComputeEngine,
+				semconv.HostID("1472385723456792345"),
+				semconv.HostName("my-mig-1234"),
+				semconv.GCPGceInstanceNameKey.String("my-mig-1234"),
+				semconv.GCPGceInstanceHostnameKey.String("hostname"),
+				semconv.HostType("n1-standard1"),
+				semconv.CloudRegion("us-central1"),
+				semconv.CloudAvailabilityZone("us-central1-c"),
+				gceInstanceGroupManagerNameKey.String("my-mig"),
+				gceInstanceGroupManagerRegionKey.String("us-central1"),
+			),
+		},
 		{
 			desc: "Cloud Functions",
 			detector: &detector{detector: &fakeGCPDetector{
@@ -242,6 +321,12 @@ type fakeGCPDetector struct {
 	gceHostName               string
 	gcpGceInstanceName        string
 	gcpGceInstanceHostname    string
+	gkeNamespaceName          string
+	gkePodName                string
+	gkeContainerName          string
+	cloudRunJobExecution      string
+	cloudRunJobTaskIndex      string
+	gceInstanceGroupManager   instanceGroupManager
 }
 
 func (f *fakeGCPDetector) ProjectID() (string, error) {
@@ -397,3 +482,89 @@ func (f *fakeGCPDetector) GCEInstanceHostname() (string, error) {
 	}
 	return f.gcpGceInstanceHostname, nil
 }
+
+func (f *fakeGCPDetector) GKENamespaceName() (string, error) {
+	if f.err != nil {
+		return "", f.err
+	}
+	return f.gkeNamespaceName, nil
+}
+
+func (f *fakeGCPDetector) GKEPodName() (string, error) {
+	if f.err != nil {
+		return "", f.err
+	}
+	return f.gkePodName, nil
+}
+
+func (f *fakeGCPDetector) GKEContainerName() (string, error) {
+	if f.err != nil {
+		return "", f.err
+	}
+	return f.gkeContainerName, nil
+}
+
+func (f *fakeGCPDetector) CloudRunJobExecution() (string, error) {
+	if f.err != nil {
+		return "", f.err
+	}
+	return f.cloudRunJobExecution, nil
+}
+
+func (f *fakeGCPDetector) CloudRunJobTaskIndex() (string, error) {
+	if f.err != nil {
+		return "", f.err
+	}
+	return f.cloudRunJobTaskIndex, nil
+}
+
+func (f *fakeGCPDetector) GCEInstanceGroupManager() (instanceGroupManager, error) {
+	if f.err != nil {
+		return instanceGroupManager{}, f.err
+	}
+	return f.gceInstanceGroupManager, nil
+}
+
+func TestParseInstanceGroupManager(t *testing.T) {
+	for _, tc := range []struct {
+		createdBy string
+		expected  instanceGroupManager
+	}{
+		{
+			createdBy: "projects/123456789012/zones/us-central1-a/instanceGroupManagers/my-mig",
+			expected:  instanceGroupManager{Name: "my-mig", Location: "us-central1-a", Type: gcp.Zone},
+		},
+		{
+			createdBy: "projects/123456789012/regions/us-central1/instanceGroupManagers/my-mig",
+			expected:  instanceGroupManager{Name: "my-mig", Location: "us-central1", Type: gcp.Region},
+		},
+		{
+			createdBy: "projects/123456789012/zones/us-central1-a/instances/my-instance",
+		},
+		{
+			createdBy: "",
+		},
+	} {
+		assert.Equal(t, tc.expected, parseInstanceGroupManager(tc.createdBy), tc.createdBy)
+	}
+}
+
+func TestExtendedDetectorKubernetes(t *testing.T) {
+	t.Setenv("K8S_NAMESPACE_NAME", "")
+	t.Setenv("NAMESPACE_NAME", "my-namespace")
+	t.Setenv("K8S_POD_NAME", "my-pod")
+	t.Setenv("POD_NAME", "ignored")
+	t.Setenv("K8S_CONTAINER_NAME", "")
+	t.Setenv("CONTAINER_NAME", "")
+
+	d := &extendedDetector{}
+	namespace, err := d.GKENamespaceName()
+	assert.NoError(t, err)
+	assert.Equal(t, "my-namespace", namespace)
+	pod, err := d.GKEPodName()
+	assert.NoError(t, err)
+	assert.Equal(t, "my-pod", pod)
+	container, err := d.GKEContainerName()
+	assert.NoError(t, err)
+	assert.Equal(t, "", container)
+}
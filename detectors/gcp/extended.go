@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp // import "go.opentelemetry.io/contrib/detectors/gcp"
+
+import (
+	"errors"
+	"strings"
+
+	"cloud.google.com/go/compute/metadata"
+	"github.com/GoogleCloudPlatform/opentelemetry-operations-go/detectors/gcp"
+
+	"go.opentelemetry.io/contrib/detectors/gcp/internal/containerutil"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// Resource attributes of GCP that are not defined by the semantic
+// conventions this package uses.
+const (
+	cloudRunJobExecutionKey          = attribute.Key("gcp.cloud_run.job.execution")
+	cloudRunJobTaskIndexKey          = attribute.Key("gcp.cloud_run.job.task_index")
+	gceInstanceGroupManagerNameKey   = attribute.Key("gcp.gce.instance_group_manager.name")
+	gceInstanceGroupManagerZoneKey   = attribute.Key("gcp.gce.instance_group_manager.zone")
+	gceInstanceGroupManagerRegionKey = attribute.Key("gcp.gce.instance_group_manager.region")
+)
+
+const (
+	createdByInstanceAttribute       = "created-by"
+	instanceGroupManagersPathSegment = "instanceGroupManagers"
+)
+
+// extendedDetector is the gcpDetector detecting the attributes the GCP
+// detection library does not support.
+type extendedDetector struct {
+	*gcp.Detector
+}
+
+// compile time assertion that extendedDetector implements the gcpDetector interface.
+var _ gcpDetector = (*extendedDetector)(nil)
+
+// GKENamespaceName returns the namespace of the pod, or an empty string if
+// it is not set in the environment.
+func (d *extendedDetector) GKENamespaceName() (string, error) {
+	return containerutil.LookupEnv(containerutil.NamespaceEnvVars...), nil
+}
+
+// GKEPodName returns the name of the pod, or an empty string if it is not
+// set in the environment.
+func (d *extendedDetector) GKEPodName() (string, error) {
+	return containerutil.LookupEnv(containerutil.PodNameEnvVars...), nil
+}
+
+// GKEContainerName returns the name of the container in the pod, or an
+// empty string if it is not set in the environment.
+func (d *extendedDetector) GKEContainerName() (string, error) {
+	return containerutil.LookupEnv(containerutil.ContainerNameEnvVars...), nil
+}
+
+// GCEInstanceGroupManager returns the managed instance group that created
+// the instance, from the created-by attribute of the instance, e.g.
+// "projects/123/zones/us-central1-a/instanceGroupManagers/my-mig". A zero
+// instanceGroupManager is returned if the instance is not part of a managed
+// instance group.
+func (d *extendedDetector) GCEInstanceGroupManager() (instanceGroupManager, error) {
+	createdBy, err := metadata.InstanceAttributeValue(createdByInstanceAttribute)
+	var notDefined metadata.NotDefinedError
+	if errors.As(err, &notDefined) {
+		return instanceGroupManager{}, nil
+	}
+	if err != nil {
+		return instanceGroupManager{}, err
+	}
+	return parseInstanceGroupManager(createdBy), nil
+}
+
+// parseInstanceGroupManager returns the managed instance group of the
+// created-by attribute of an instance.
+func parseInstanceGroupManager(createdBy string) instanceGroupManager {
+	parts := strings.Split(createdBy, "/")
+	if len(parts) != 6 || parts[4] != instanceGroupManagersPathSegment {
+		return instanceGroupManager{}
+	}
+	mig := instanceGroupManager{Name: parts[5], Location: parts[3]}
+	switch parts[2] {
+	case "zones":
+		mig.Type = gcp.Zone
+	case "regions":
+		mig.Type = gcp.Region
+	}
+	return mig
+}
@@ -23,13 +23,12 @@ import (
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/sdk/resource"
-	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
 )
 
 // GKE collects resource information of GKE computing instances.
 //
-// Deprecated: Use gcp.NewDetector() instead, which does NOT detect container, pod, and namespace attributes.
-// Set those using name using the OTEL_RESOURCE_ATTRIBUTES env var instead.
+// Deprecated: Use gcp.NewDetector() instead, which detects the container, pod, and namespace attributes from the downward API environment variables.
 type GKE struct{}
 
 // compile time assertion that GKE implements the resource.Detector interface.
@@ -0,0 +1,55 @@
+// Code created by gotmpl. DO NOT MODIFY.
+// source: internal/shared/containerutil/env.go.tmpl
+
+// Copyright The OpenTelemetry Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package containerutil
+
+import "os"
+
+// Environment variables commonly set in the pod spec with the Kubernetes
+// downward API, in order of precedence.
+var (
+	PodNameEnvVars        = []string{"K8S_POD_NAME", "POD_NAME"}
+	PodUIDEnvVars         = []string{"K8S_POD_UID", "POD_UID"}
+	NamespaceEnvVars      = []string{"K8S_NAMESPACE_NAME", "POD_NAMESPACE", "NAMESPACE_NAME"}
+	NodeNameEnvVars       = []string{"K8S_NODE_NAME", "NODE_NAME"}
+	ContainerNameEnvVars  = []string{"K8S_CONTAINER_NAME", "CONTAINER_NAME"}
+	DeploymentNameEnvVars = []string{"K8S_DEPLOYMENT_NAME"}
+)
+
+// LookupEnv returns the value of the first of the environment variables
+// keys that is set, or an empty string.
+func LookupEnv(keys ...string) string {
+	for _, key := range keys {
+		if v := os.Getenv(key); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// PodName returns the value of the first of the environment variables keys
+// that is set or, if none is, the hostname returned by hostname. The hostname
+// of a pod is its name, unless it uses the host network.
+func PodName(hostname func() (string, error), keys ...string) string {
+	if name := LookupEnv(keys...); name != "" {
+		return name
+	}
+	if hostname == nil {
+		return ""
+	}
+	name, _ := hostname()
+	return name
+}
@@ -23,10 +23,15 @@ type gcpDetector interface {
 	GKEAvailabilityZoneOrRegion() (string, gcp.LocationType, error)
 	GKEClusterName() (string, error)
 	GKEHostID() (string, error)
+	GKENamespaceName() (string, error)
+	GKEPodName() (string, error)
+	GKEContainerName() (string, error)
 	FaaSName() (string, error)
 	FaaSVersion() (string, error)
 	FaaSID() (string, error)
 	FaaSCloudRegion() (string, error)
+	CloudRunJobExecution() (string, error)
+	CloudRunJobTaskIndex() (string, error)
 	AppEngineFlexAvailabilityZoneAndRegion() (string, string, error)
 	AppEngineStandardAvailabilityZone() (string, error)
 	AppEngineStandardCloudRegion() (string, error)
@@ -39,4 +44,13 @@ type gcpDetector interface {
 	GCEHostName() (string, error)
 	GCEInstanceHostname() (string, error)
 	GCEInstanceName() (string, error)
+	GCEInstanceGroupManager() (instanceGroupManager, error)
+}
+
+// instanceGroupManager is the managed instance group (MIG) of a GCE
+// instance.
+type instanceGroupManager struct {
+	Name     string
+	Location string
+	Type     gcp.LocationType
 }
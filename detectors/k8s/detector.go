@@ -0,0 +1,150 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package k8s provides a resource detector for processes running in a
+// Kubernetes pod, on any cluster.
+//
+// The detector reads the metadata of the pod from the environment variables
+// set with the Kubernetes downward API, the service account mounted in the
+// pod, the hostname and the cgroups of the process. It does not access the
+// Kubernetes API.
+package k8s // import "go.opentelemetry.io/contrib/detectors/k8s"
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"go.opentelemetry.io/contrib/detectors/k8s/internal/containerutil"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+)
+
+const (
+	serviceHostEnvVar     = "KUBERNETES_SERVICE_HOST"
+	defaultServiceAccount = "/var/run/secrets/kubernetes.io/serviceaccount"
+)
+
+// clusterNameEnvVars are the environment variables of the cluster name, in
+// order of precedence.
+var clusterNameEnvVars = []string{"K8S_CLUSTER_NAME"}
+
+// cgroupPodUID matches the pod UID in a cgroup v1 path, written with dashes
+// by the cgroupfs driver and with underscores by the systemd driver, e.g.
+// "/kubepods-besteffort-pod<uid>.slice".
+var cgroupPodUID = regexp.MustCompile(`pod([0-9a-f]{8}[-_][0-9a-f]{4}[-_][0-9a-f]{4}[-_][0-9a-f]{4}[-_][0-9a-f]{12})`)
+
+// resourceDetector detects the resource of a process running in a
+// Kubernetes pod.
+type resourceDetector struct {
+	serviceAccountDir string
+	cgroupPath        string
+	mountinfoPath     string
+	hostname          func() (string, error)
+}
+
+// compile time assertion that resourceDetector implements the resource.Detector interface.
+var _ resource.Detector = (*resourceDetector)(nil)
+
+// NewResourceDetector returns a resource detector that detects the
+// Kubernetes pod the process runs in.
+func NewResourceDetector() resource.Detector {
+	return &resourceDetector{
+		serviceAccountDir: defaultServiceAccount,
+		cgroupPath:        containerutil.DefaultCgroupPath,
+		mountinfoPath:     containerutil.DefaultMountinfoPath,
+		hostname:          os.Hostname,
+	}
+}
+
+// Detect returns a Resource describing the Kubernetes pod the process runs
+// in, with the k8s.cluster.name, k8s.node.name, k8s.namespace.name,
+// k8s.pod.name, k8s.pod.uid, k8s.container.name, k8s.deployment.name and
+// container.id attributes that could be detected.
+//
+// An empty resource is returned when the process does not run in Kubernetes.
+func (detector *resourceDetector) Detect(context.Context) (*resource.Resource, error) {
+	if !detector.isK8s() {
+		return resource.Empty(), nil
+	}
+
+	var cgroup string
+	if data, err := os.ReadFile(detector.cgroupPath); err == nil {
+		cgroup = string(data)
+	}
+
+	var attributes []attribute.KeyValue
+	add := func(key attribute.Key, value string) {
+		if value != "" {
+			attributes = append(attributes, key.String(value))
+		}
+	}
+
+	add(semconv.K8SClusterNameKey, containerutil.LookupEnv(clusterNameEnvVars...))
+	add(semconv.K8SNodeNameKey, containerutil.LookupEnv(containerutil.NodeNameEnvVars...))
+
+	namespace := containerutil.LookupEnv(containerutil.NamespaceEnvVars...)
+	if namespace == "" {
+		namespace = detector.serviceAccountNamespace()
+	}
+	add(semconv.K8SNamespaceNameKey, namespace)
+
+	add(semconv.K8SPodNameKey, containerutil.PodName(detector.hostname, containerutil.PodNameEnvVars...))
+
+	podUID := containerutil.LookupEnv(containerutil.PodUIDEnvVars...)
+	if podUID == "" {
+		podUID = podUIDFromCgroup(cgroup)
+	}
+	add(semconv.K8SPodUIDKey, podUID)
+
+	add(semconv.K8SContainerNameKey, containerutil.LookupEnv(containerutil.ContainerNameEnvVars...))
+	add(semconv.K8SDeploymentNameKey, containerutil.LookupEnv(containerutil.DeploymentNameEnvVars...))
+
+	add(semconv.ContainerIDKey, containerutil.ContainerID(detector.cgroupPath, detector.mountinfoPath))
+
+	return resource.NewWithAttributes(semconv.SchemaURL, attributes...), nil
+}
+
+// isK8s returns if the process runs in a Kubernetes pod, which has the
+// address of the Kubernetes API set in its environment, and usually a
+// service account token mounted.
+func (detector *resourceDetector) isK8s() bool {
+	if os.Getenv(serviceHostEnvVar) != "" {
+		return true
+	}
+	info, err := os.Stat(detector.serviceAccountDir)
+	return err == nil && info.IsDir()
+}
+
+// serviceAccountNamespace returns the namespace of the service account
+// mounted in the pod, or an empty string.
+func (detector *resourceDetector) serviceAccountNamespace() string {
+	data, err := os.ReadFile(filepath.Join(detector.serviceAccountDir, "namespace"))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// podUIDFromCgroup returns the pod UID found in the content of a cgroup v1
+// /proc/self/cgroup file, or an empty string.
+func podUIDFromCgroup(data string) string {
+	if m := cgroupPodUID.FindStringSubmatch(data); m != nil {
+		return strings.ReplaceAll(m[1], "_", "-")
+	}
+	return ""
+}
@@ -0,0 +1,161 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/contrib/detectors/k8s/internal/containerutil"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+)
+
+const (
+	containerID = "1f5b0a6f3e2d4c7b8a9e0d1c2b3a4f5e6d7c8b9a0f1e2d3c4b5a69788796a5b4"
+	podUID      = "c5b9b3b6-1d6e-4b8a-9d49-1e0f9e3c2a41"
+)
+
+// clearEnv unsets the environment variables read by the detector that are
+// not set by the test.
+func clearEnv(t *testing.T) {
+	for _, keys := range [][]string{
+		clusterNameEnvVars,
+		containerutil.NodeNameEnvVars,
+		containerutil.NamespaceEnvVars,
+		containerutil.PodNameEnvVars,
+		containerutil.PodUIDEnvVars,
+		containerutil.ContainerNameEnvVars,
+		containerutil.DeploymentNameEnvVars,
+	} {
+		for _, key := range keys {
+			if _, ok := os.LookupEnv(key); ok {
+				t.Setenv(key, "")
+			}
+		}
+	}
+}
+
+func newTestDetector(t *testing.T, files map[string]string) *resourceDetector {
+	dir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "serviceaccount"), 0o755))
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+	}
+	return &resourceDetector{
+		serviceAccountDir: filepath.Join(dir, "serviceaccount"),
+		cgroupPath:        filepath.Join(dir, "cgroup"),
+		mountinfoPath:     filepath.Join(dir, "mountinfo"),
+		hostname:          func() (string, error) { return "my-pod-5d4f8b-x2x9z", nil },
+	}
+}
+
+func TestDetect(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("K8S_CLUSTER_NAME", "my-cluster")
+	t.Setenv("NODE_NAME", "my-node")
+	t.Setenv("CONTAINER_NAME", "my-container")
+	t.Setenv("K8S_DEPLOYMENT_NAME", "my-pod")
+
+	detector := newTestDetector(t, map[string]string{
+		"serviceaccount/namespace": "my-namespace\n",
+		"cgroup": "12:pids:/kubepods/besteffort/pod" + podUID + "/" + containerID + "\n" +
+			"1:name=systemd:/kubepods/besteffort/pod" + podUID + "/" + containerID + "\n",
+	})
+	r, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+
+	expected := resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.K8SClusterName("my-cluster"),
+		semconv.K8SNodeName("my-node"),
+		semconv.K8SNamespaceName("my-namespace"),
+		semconv.K8SPodName("my-pod-5d4f8b-x2x9z"),
+		semconv.K8SPodUID(podUID),
+		semconv.K8SContainerName("my-container"),
+		semconv.K8SDeploymentName("my-pod"),
+		semconv.ContainerID(containerID),
+	)
+	assert.Equal(t, expected, r)
+}
+
+func TestDetectDownwardAPI(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("K8S_NAMESPACE_NAME", "other-namespace")
+	t.Setenv("K8S_POD_NAME", "other-pod")
+	t.Setenv("K8S_POD_UID", "1")
+
+	detector := newTestDetector(t, map[string]string{
+		"serviceaccount/namespace": "my-namespace",
+		"cgroup":                   "0::/",
+		"mountinfo":                "749 736 259:1 /var/lib/docker/containers/" + containerID + "/hostname /etc/hostname rw,relatime - ext4 /dev/root rw",
+	})
+	r, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+
+	expected := resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.K8SNamespaceName("other-namespace"),
+		semconv.K8SPodName("other-pod"),
+		semconv.K8SPodUID("1"),
+		semconv.ContainerID(containerID),
+	)
+	assert.Equal(t, expected, r)
+}
+
+func TestDetectNotK8s(t *testing.T) {
+	t.Setenv(serviceHostEnvVar, "")
+
+	detector := newTestDetector(t, nil)
+	detector.serviceAccountDir = filepath.Join(t.TempDir(), "missing")
+	r, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, resource.Empty(), r)
+}
+
+func TestPodUIDFromCgroup(t *testing.T) {
+	tests := []struct {
+		name   string
+		cgroup string
+		podUID string
+	}{
+		{
+			name:   "cgroupfs",
+			cgroup: "11:memory:/kubepods/burstable/pod" + podUID + "/" + containerID,
+			podUID: podUID,
+		},
+		{
+			name:   "systemd containerd",
+			cgroup: "0::/kubepods.slice/kubepods-besteffort.slice/kubepods-besteffort-podc5b9b3b6_1d6e_4b8a_9d49_1e0f9e3c2a41.slice/cri-containerd-" + containerID + ".scope",
+			podUID: podUID,
+		},
+		{
+			name:   "docker",
+			cgroup: "12:pids:/docker/" + containerID,
+		},
+		{
+			name:   "cgroup v2",
+			cgroup: "0::/",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.podUID, podUIDFromCgroup(tt.cgroup))
+		})
+	}
+}
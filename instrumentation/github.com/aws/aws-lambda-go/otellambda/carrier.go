@@ -0,0 +1,138 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otellambda // import "go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-lambda-go/otellambda"
+
+import (
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// HeaderMapCarrier is a TextMapCarrier that uses the single-value headers of
+// API Gateway and Application Load Balancer events to store and retrieve
+// values. Keys are matched case-insensitively.
+type HeaderMapCarrier map[string]string
+
+var _ propagation.TextMapCarrier = HeaderMapCarrier{}
+
+// Get returns the value associated with the passed key.
+func (c HeaderMapCarrier) Get(key string) string {
+	if v, ok := c[key]; ok {
+		return v
+	}
+	for k, v := range c {
+		if strings.EqualFold(k, key) {
+			return v
+		}
+	}
+	return ""
+}
+
+// Set stores the key-value pair.
+func (c HeaderMapCarrier) Set(key, value string) {
+	c[key] = value
+}
+
+// Keys lists the keys stored in this carrier.
+func (c HeaderMapCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// newHeaderMapCarrier returns a HeaderMapCarrier holding headers and
+// multiValueHeaders, the values of the latter being joined with a comma.
+func newHeaderMapCarrier(headers map[string]string, multiValueHeaders map[string][]string) HeaderMapCarrier {
+	c := make(HeaderMapCarrier, len(headers)+len(multiValueHeaders))
+	for k, v := range multiValueHeaders {
+		c[k] = strings.Join(v, ",")
+	}
+	for k, v := range headers {
+		c[k] = v
+	}
+	return c
+}
+
+// SQSMessageAttributeCarrier is a TextMapCarrier that uses the
+// MessageAttributes of an SQS message delivered to Lambda to store and
+// retrieve values.
+type SQSMessageAttributeCarrier map[string]events.SQSMessageAttribute
+
+var _ propagation.TextMapCarrier = SQSMessageAttributeCarrier{}
+
+// Get returns the string value associated with the passed key.
+func (c SQSMessageAttributeCarrier) Get(key string) string {
+	v, ok := c[key]
+	if !ok || v.StringValue == nil {
+		return ""
+	}
+	return *v.StringValue
+}
+
+// Set stores the key-value pair as a String message attribute.
+func (c SQSMessageAttributeCarrier) Set(key, value string) {
+	c[key] = events.SQSMessageAttribute{
+		DataType:    "String",
+		StringValue: &value,
+	}
+}
+
+// Keys lists the keys stored in this carrier.
+func (c SQSMessageAttributeCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// SNSMessageAttributeCarrier is a TextMapCarrier that uses the
+// MessageAttributes of an SNS notification delivered to Lambda to store and
+// retrieve values. Each attribute is an object of the form
+// {"Type":"String","Value":"..."}.
+type SNSMessageAttributeCarrier map[string]interface{}
+
+var _ propagation.TextMapCarrier = SNSMessageAttributeCarrier{}
+
+// Get returns the string value associated with the passed key.
+func (c SNSMessageAttributeCarrier) Get(key string) string {
+	attr, ok := c[key].(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	v, _ := attr["Value"].(string)
+	return v
+}
+
+// Set stores the key-value pair as a String message attribute.
+func (c SNSMessageAttributeCarrier) Set(key, value string) {
+	c[key] = map[string]interface{}{
+		"Type":  "String",
+		"Value": value,
+	}
+}
+
+// Keys lists the keys stored in this carrier.
+func (c SNSMessageAttributeCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
@@ -16,7 +16,9 @@ package otellambda // import "go.opentelemetry.io/contrib/instrumentation/github
 
 import (
 	"context"
+	"time"
 
+	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
 )
@@ -53,6 +55,14 @@ func emptyEventToCarrier([]byte) propagation.TextMapCarrier {
 // Compile time check our emptyEventToCarrier implements EventToCarrier.
 var _ EventToCarrier = emptyEventToCarrier
 
+// A KinesisRecordToCarrier function returns a TextMapCarrier holding the
+// trace context wrapped with the data of a Kinesis record by its producer,
+// and an empty carrier if the data is not wrapped.
+type KinesisRecordToCarrier func(data []byte) propagation.TextMapCarrier
+
+// Compile time check our jsonKinesisRecordToCarrier implements KinesisRecordToCarrier.
+var _ KinesisRecordToCarrier = jsonKinesisRecordToCarrier
+
 // Option applies a configuration option.
 type Option interface {
 	apply(*config)
@@ -71,6 +81,12 @@ type config struct {
 	// returned by otel.GetTracerProvider()
 	TracerProvider trace.TracerProvider
 
+	// MeterProvider is the MeterProvider which will be used
+	// to create the instruments measuring invocations
+	// The default value of MeterProvider the global otel MeterProvider
+	// returned by otel.GetMeterProvider()
+	MeterProvider metric.MeterProvider
+
 	// Flusher is the mechanism used to flush any unexported spans
 	// each Lambda Invocation to avoid spans being unexported for long
 	// when periods of time if Lambda freezes the execution environment
@@ -91,6 +107,35 @@ type config struct {
 	// The default value of Propagator the global otel Propagator
 	// returned by otel.GetTextMapPropagator()
 	Propagator propagation.TextMapPropagator
+
+	// EventSourceDetection enables the detection of the source of the
+	// invocation events, see WithEventSourceDetection.
+	// The default value of EventSourceDetection is false
+	EventSourceDetection bool
+
+	// KinesisRecordToCarrier is the mechanism used to retrieve the trace
+	// context from the data of Kinesis records, see
+	// WithKinesisRecordToCarrier.
+	// The default value of KinesisRecordToCarrier is
+	// jsonKinesisRecordToCarrier which reads the default envelope of the
+	// otelaws instrumentation
+	KinesisRecordToCarrier KinesisRecordToCarrier
+
+	// RecordSpans enables the creation of a process span for every record
+	// of SQS and Kinesis events, see WithRecordSpans.
+	// The default value of RecordSpans is false
+	RecordSpans bool
+
+	// RemainingTimeWarning is the remaining time of an invocation below
+	// which an event is added to its span, see WithRemainingTimeWarning.
+	// The default value of RemainingTimeWarning is 0, which disables the
+	// event
+	RemainingTimeWarning time.Duration
+
+	// Setup is called on the first invocation to return options completing
+	// the configuration, see WithSetup.
+	// The default value of Setup is nil
+	Setup func(ctx context.Context) []Option
 }
 
 // WithTracerProvider configures the TracerProvider used by the
@@ -103,6 +148,23 @@ func WithTracerProvider(tracerProvider trace.TracerProvider) Option {
 	})
 }
 
+// WithMeterProvider configures the MeterProvider used by the
+// instrumentation. If the MeterProvider implements Flusher, like the
+// MeterProvider of the SDK, it is flushed at the end of each invocation.
+//
+// An invocation is counted in faas.timeouts, and its span ended as failed,
+// when it has not returned 50ms before the deadline of its context, leaving
+// time to flush the telemetry before the function is stopped. A handler
+// returning successfully within these last 50ms is thus recorded as timed
+// out.
+//
+// By default, the global MeterProvider is used.
+func WithMeterProvider(meterProvider metric.MeterProvider) Option {
+	return optionFunc(func(c *config) {
+		c.MeterProvider = meterProvider
+	})
+}
+
 // WithFlusher sets the used flusher.
 func WithFlusher(flusher Flusher) Option {
 	return optionFunc(func(c *config) {
@@ -125,3 +187,93 @@ func WithPropagator(propagator propagation.TextMapPropagator) Option {
 		c.Propagator = propagator
 	})
 }
+
+// WithEventSourceDetection enables the detection of the source of the
+// invocation events from their shape. API Gateway REST and HTTP APIs,
+// Application Load Balancers, SQS, SNS, Kinesis and EventBridge events are
+// recognized.
+//
+// The faas.trigger attribute, as well as HTTP or messaging attributes
+// depending on the source, are set on the span of the invocation. The trace
+// context is extracted with the configured Propagator from the headers of
+// HTTP events, the message attributes of SQS and SNS messages, the data of
+// Kinesis records, see WithKinesisRecordToCarrier, and the top level
+// string fields of the detail of EventBridge events. If found, it takes
+// precedence over the trace context returned by the EventToCarrier.
+// Invocations with a batch of several messages are not parented to any
+// message, instead their span is linked to the trace context of every
+// message.
+//
+// The HTTP status of the events.APIGatewayProxyResponse,
+// events.APIGatewayV2HTTPResponse and events.ALBTargetGroupResponse returned
+// by handlers wrapped with InstrumentHandler is always recorded. The JSON
+// encoded response of a lambda.Handler wrapped with WrapHandler is only
+// inspected for an HTTP status when this option detects an HTTP event.
+func WithEventSourceDetection() Option {
+	return optionFunc(func(c *config) {
+		c.EventSourceDetection = true
+	})
+}
+
+// WithKinesisRecordToCarrier sets the KinesisRecordToCarrier used to
+// extract the trace context of Kinesis records detected by
+// WithEventSourceDetection.
+//
+// By default, the trace context is read from the JSON envelope the otelaws
+// instrumentation wraps records with by default,
+// otelaws.JSONKinesisRecordEnvelope. Records written with another
+// otelaws.KinesisRecordEnvelope are read by a KinesisRecordToCarrier calling
+// its Unwrap method.
+func WithKinesisRecordToCarrier(kinesisRecordToCarrier KinesisRecordToCarrier) Option {
+	return optionFunc(func(c *config) {
+		c.KinesisRecordToCarrier = kinesisRecordToCarrier
+	})
+}
+
+// WithRecordSpans enables the creation of a process span for every record of
+// SQS and Kinesis events, as a child of the span of the invocation. The span
+// of a record is linked to the trace context of the producer of the record.
+// It implies WithEventSourceDetection.
+//
+// The handler times the processing of a record by calling StartRecord with
+// the context of the invocation; the spans of the records it does not start
+// last as long as the invocation. The spans are marked as failed if the
+// handler returns an error, or if the response of the handler lists the
+// record in its batchItemFailures, e.g. with events.SQSEventResponse or
+// events.KinesisEventResponse.
+func WithRecordSpans() Option {
+	return optionFunc(func(c *config) {
+		c.EventSourceDetection = true
+		c.RecordSpans = true
+	})
+}
+
+// WithRemainingTimeWarning adds a "remaining time low" event to the span of
+// invocations whose remaining time, as set by the deadline of their context,
+// drops below threshold. A threshold less than or equal to zero disables the
+// event.
+//
+// By default, the event is disabled.
+func WithRemainingTimeWarning(threshold time.Duration) Option {
+	return optionFunc(func(c *config) {
+		c.RemainingTimeWarning = threshold
+	})
+}
+
+// WithSetup configures a function called once, on the first invocation and
+// before its span is started, with the context of the invocation. The
+// options it returns are applied to the instrumentation.
+//
+// The context holds the lambdacontext.LambdaContext of the invocation, which
+// the account and the ARN of the function are only known from. setup can pass
+// it to the Detect method of the Lambda resource detector
+// (go.opentelemetry.io/contrib/detectors/aws/lambda) to create the
+// TracerProvider and MeterProvider with a complete resource, shared by all
+// signals, and return them with WithTracerProvider, WithMeterProvider and
+// WithFlusher. The cloud.account.id attribute is then no longer added to the
+// spans of the invocations.
+func WithSetup(setup func(ctx context.Context) []Option) Option {
+	return optionFunc(func(c *config) {
+		c.Setup = setup
+	})
+}
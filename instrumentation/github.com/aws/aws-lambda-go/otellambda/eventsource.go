@@ -0,0 +1,437 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otellambda // import "go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-lambda-go/otellambda"
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// eventSource describes the source of an invocation event, as detected from
+// the shape of the event.
+type eventSource struct {
+	// kind is the kind of the span of the invocation.
+	kind trace.SpanKind
+	// attrs are the attributes describing the event.
+	attrs []attribute.KeyValue
+	// records hold the trace context of the event. HTTP events have a
+	// single record, batches of messages have one record per message.
+	records []eventRecord
+	// destination is the name of the queue or stream of SQS and Kinesis
+	// records, for which process spans can be created.
+	destination string
+}
+
+// eventRecord is a record of an invocation event.
+type eventRecord struct {
+	// carrier holds the trace context of the record.
+	carrier propagation.TextMapCarrier
+	// itemIdentifier identifies the record in the batchItemFailures of the
+	// response of the function, if it supports partial batch failures.
+	itemIdentifier string
+	// attrs are the attributes of the process span of the record.
+	attrs []attribute.KeyValue
+}
+
+// eventProbe holds the fields used to detect the source of an event.
+type eventProbe struct {
+	Records []struct {
+		EventSource string `json:"eventSource"`
+	} `json:"Records"`
+	HTTPMethod     string `json:"httpMethod"`
+	Version        string `json:"version"`
+	RequestContext *struct {
+		ELB  json.RawMessage `json:"elb"`
+		HTTP json.RawMessage `json:"http"`
+	} `json:"requestContext"`
+	DetailType *string `json:"detail-type"`
+}
+
+// detectEventSource returns the source of the event eventJSON. Events that
+// are not recognized are described with a faas.trigger of "other". The trace
+// context of Kinesis records is read with kinesisRecordToCarrier.
+func detectEventSource(eventJSON []byte, kinesisRecordToCarrier KinesisRecordToCarrier) eventSource {
+	other := eventSource{
+		kind:  trace.SpanKindServer,
+		attrs: []attribute.KeyValue{semconv.FaaSTriggerOther},
+	}
+
+	var probe eventProbe
+	if err := json.Unmarshal(eventJSON, &probe); err != nil {
+		return other
+	}
+
+	var (
+		src eventSource
+		err error
+	)
+	switch {
+	case len(probe.Records) > 0:
+		switch probe.Records[0].EventSource {
+		case "aws:sqs":
+			src, err = sqsEventSource(eventJSON)
+		case "aws:sns":
+			src, err = snsEventSource(eventJSON)
+		case "aws:kinesis":
+			src, err = kinesisEventSource(eventJSON, kinesisRecordToCarrier)
+		default:
+			return other
+		}
+	case probe.RequestContext != nil && len(probe.RequestContext.ELB) > 0:
+		src, err = albEventSource(eventJSON)
+	case probe.Version == "2.0" && probe.RequestContext != nil && len(probe.RequestContext.HTTP) > 0:
+		src, err = apiGatewayV2EventSource(eventJSON)
+	case probe.HTTPMethod != "" && probe.RequestContext != nil:
+		src, err = apiGatewayEventSource(eventJSON)
+	case probe.DetailType != nil:
+		src, err = eventBridgeEventSource(eventJSON)
+	default:
+		return other
+	}
+	if err != nil {
+		return other
+	}
+	return src
+}
+
+func apiGatewayEventSource(eventJSON []byte) (eventSource, error) {
+	var event events.APIGatewayProxyRequest
+	if err := json.Unmarshal(eventJSON, &event); err != nil {
+		return eventSource{}, err
+	}
+
+	headers := newHeaderMapCarrier(event.Headers, event.MultiValueHeaders)
+	query := url.Values(event.MultiValueQueryStringParameters).Encode()
+	if query == "" {
+		query = singleValues(event.QueryStringParameters).Encode()
+	}
+	attrs := httpAttributes(event.HTTPMethod, event.Path, query, headers)
+	if event.Resource != "" {
+		attrs = append(attrs, semconv.HTTPRoute(event.Resource))
+	}
+	attrs = appendHTTPClient(attrs, headers, event.RequestContext.Identity.SourceIP, event.RequestContext.Identity.UserAgent)
+	attrs = appendHTTPServer(attrs, headers, event.RequestContext.DomainName)
+
+	return eventSource{
+		kind:    trace.SpanKindServer,
+		attrs:   attrs,
+		records: []eventRecord{{carrier: headers}},
+	}, nil
+}
+
+func apiGatewayV2EventSource(eventJSON []byte) (eventSource, error) {
+	var event events.APIGatewayV2HTTPRequest
+	if err := json.Unmarshal(eventJSON, &event); err != nil {
+		return eventSource{}, err
+	}
+
+	headers := HeaderMapCarrier(event.Headers)
+	if headers == nil {
+		headers = HeaderMapCarrier{}
+	}
+	path := event.RawPath
+	if path == "" {
+		path = event.RequestContext.HTTP.Path
+	}
+	attrs := httpAttributes(event.RequestContext.HTTP.Method, path, event.RawQueryString, headers)
+	// The route key has the form "<method> <path>", or is "$default".
+	if _, route, ok := strings.Cut(event.RouteKey, " "); ok {
+		attrs = append(attrs, semconv.HTTPRoute(route))
+	}
+	attrs = appendHTTPClient(attrs, headers, event.RequestContext.HTTP.SourceIP, event.RequestContext.HTTP.UserAgent)
+	attrs = appendHTTPServer(attrs, headers, event.RequestContext.DomainName)
+
+	return eventSource{
+		kind:    trace.SpanKindServer,
+		attrs:   attrs,
+		records: []eventRecord{{carrier: headers}},
+	}, nil
+}
+
+func albEventSource(eventJSON []byte) (eventSource, error) {
+	var event events.ALBTargetGroupRequest
+	if err := json.Unmarshal(eventJSON, &event); err != nil {
+		return eventSource{}, err
+	}
+
+	headers := newHeaderMapCarrier(event.Headers, event.MultiValueHeaders)
+	query := url.Values(event.MultiValueQueryStringParameters).Encode()
+	if query == "" {
+		query = singleValues(event.QueryStringParameters).Encode()
+	}
+	attrs := httpAttributes(event.HTTPMethod, event.Path, query, headers)
+	attrs = appendHTTPClient(attrs, headers, "", "")
+	attrs = appendHTTPServer(attrs, headers, "")
+
+	return eventSource{
+		kind:    trace.SpanKindServer,
+		attrs:   attrs,
+		records: []eventRecord{{carrier: headers}},
+	}, nil
+}
+
+// httpClientIPKey is the attribute otelhttp sets to the address of the
+// client, which is no longer defined by the semantic conventions this package
+// uses.
+const httpClientIPKey = attribute.Key("http.client_ip")
+
+// httpAttributes returns the attributes common to all HTTP events. Like the
+// response attributes set by setHTTPResponse, they are the ones otelhttp
+// sets on the spans of HTTP servers.
+func httpAttributes(method, path, query string, headers HeaderMapCarrier) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{semconv.FaaSTriggerHTTP}
+	if method != "" {
+		attrs = append(attrs, semconv.HTTPMethodKey.String(method))
+	}
+	target := path
+	if query != "" {
+		target += "?" + query
+	}
+	if target != "" {
+		attrs = append(attrs, semconv.HTTPTargetKey.String(target))
+	}
+	if scheme := headers.Get("X-Forwarded-Proto"); scheme != "" {
+		attrs = append(attrs, semconv.HTTPSchemeKey.String(scheme))
+	}
+	return attrs
+}
+
+// appendHTTPClient appends the address and the user agent of the client to
+// attrs. The values found in the headers are used when sourceIP or userAgent
+// are empty.
+func appendHTTPClient(attrs []attribute.KeyValue, headers HeaderMapCarrier, sourceIP, userAgent string) []attribute.KeyValue {
+	if sourceIP == "" {
+		sourceIP, _, _ = strings.Cut(headers.Get("X-Forwarded-For"), ",")
+		sourceIP = strings.TrimSpace(sourceIP)
+	}
+	if sourceIP != "" {
+		attrs = append(attrs, httpClientIPKey.String(sourceIP))
+	}
+	if userAgent == "" {
+		userAgent = headers.Get("User-Agent")
+	}
+	if userAgent != "" {
+		attrs = append(attrs, semconv.UserAgentOriginal(userAgent))
+	}
+	return attrs
+}
+
+// appendHTTPServer appends the address of the server to attrs. The Host
+// header is used when domainName is empty.
+func appendHTTPServer(attrs []attribute.KeyValue, headers HeaderMapCarrier, domainName string) []attribute.KeyValue {
+	if domainName == "" {
+		domainName = headers.Get("Host")
+	}
+	if domainName != "" {
+		attrs = append(attrs, semconv.NetHostNameKey.String(domainName))
+	}
+	return attrs
+}
+
+func singleValues(params map[string]string) url.Values {
+	values := make(url.Values, len(params))
+	for k, v := range params {
+		values.Set(k, v)
+	}
+	return values
+}
+
+func sqsEventSource(eventJSON []byte) (eventSource, error) {
+	var event events.SQSEvent
+	if err := json.Unmarshal(eventJSON, &event); err != nil {
+		return eventSource{}, err
+	}
+
+	queue := arnResource(event.Records[0].EventSourceARN)
+	records := make([]eventRecord, len(event.Records))
+	for i, record := range event.Records {
+		records[i] = eventRecord{
+			carrier:        SQSMessageAttributeCarrier(record.MessageAttributes),
+			itemIdentifier: record.MessageId,
+			attrs: append(messagingAttributes("AmazonSQS", queue, 1),
+				semconv.MessagingMessageID(record.MessageId)),
+		}
+	}
+	attrs := pubsubAttributes("AmazonSQS", queue, len(event.Records))
+	if len(event.Records) == 1 {
+		attrs = append(attrs, semconv.MessagingMessageID(event.Records[0].MessageId))
+	}
+
+	return eventSource{
+		kind:        trace.SpanKindConsumer,
+		attrs:       attrs,
+		records:     records,
+		destination: queue,
+	}, nil
+}
+
+func snsEventSource(eventJSON []byte) (eventSource, error) {
+	var event events.SNSEvent
+	if err := json.Unmarshal(eventJSON, &event); err != nil {
+		return eventSource{}, err
+	}
+
+	records := make([]eventRecord, len(event.Records))
+	for i, record := range event.Records {
+		records[i] = eventRecord{carrier: SNSMessageAttributeCarrier(record.SNS.MessageAttributes)}
+	}
+	first := event.Records[0]
+	attrs := pubsubAttributes("AmazonSNS", arnResource(first.SNS.TopicArn), len(event.Records))
+	if len(event.Records) == 1 {
+		attrs = append(attrs, semconv.MessagingMessageID(first.SNS.MessageID))
+	}
+
+	return eventSource{
+		kind:    trace.SpanKindConsumer,
+		attrs:   attrs,
+		records: records,
+	}, nil
+}
+
+// kinesisRecord is the default JSON envelope in which the otelaws
+// instrumentation wraps the data of Kinesis records together with their
+// trace context, otelaws.JSONKinesisRecordEnvelope.
+type kinesisRecord struct {
+	TraceContext propagation.MapCarrier `json:"traceContext"`
+	Data         []byte                 `json:"data"`
+}
+
+func kinesisEventSource(eventJSON []byte, kinesisRecordToCarrier KinesisRecordToCarrier) (eventSource, error) {
+	var event events.KinesisEvent
+	if err := json.Unmarshal(eventJSON, &event); err != nil {
+		return eventSource{}, err
+	}
+
+	// The ARN of a stream has the form arn:aws:kinesis:<region>:<account>:stream/<name>.
+	stream := strings.TrimPrefix(arnResource(event.Records[0].EventSourceArn), "stream/")
+	records := make([]eventRecord, len(event.Records))
+	for i, record := range event.Records {
+		records[i] = eventRecord{
+			carrier:        kinesisRecordToCarrier(record.Kinesis.Data),
+			itemIdentifier: record.Kinesis.SequenceNumber,
+			attrs: append(messagingAttributes("AmazonKinesis", stream, 1),
+				semconv.MessagingMessageID(record.EventID)),
+		}
+	}
+	attrs := pubsubAttributes("AmazonKinesis", stream, len(event.Records))
+	if len(event.Records) == 1 {
+		attrs = append(attrs, semconv.MessagingMessageID(event.Records[0].EventID))
+	}
+
+	return eventSource{
+		kind:        trace.SpanKindConsumer,
+		attrs:       attrs,
+		records:     records,
+		destination: stream,
+	}, nil
+}
+
+// jsonKinesisRecordToCarrier returns the trace context of the data of a
+// Kinesis record wrapped in the default JSON envelope of the otelaws
+// instrumentation. An empty carrier is returned for records that are not
+// wrapped.
+func jsonKinesisRecordToCarrier(data []byte) propagation.TextMapCarrier {
+	var r kinesisRecord
+	if err := json.Unmarshal(data, &r); err != nil || r.TraceContext == nil {
+		return propagation.MapCarrier{}
+	}
+	return r.TraceContext
+}
+
+func eventBridgeEventSource(eventJSON []byte) (eventSource, error) {
+	var event events.CloudWatchEvent
+	if err := json.Unmarshal(eventJSON, &event); err != nil {
+		return eventSource{}, err
+	}
+
+	// Scheduled events are emitted by EventBridge rules or the EventBridge
+	// Scheduler.
+	if event.DetailType == "Scheduled Event" {
+		attrs := []attribute.KeyValue{semconv.FaaSTriggerTimer}
+		if !event.Time.IsZero() {
+			attrs = append(attrs, semconv.FaaSTime(event.Time.UTC().Format(time.RFC3339)))
+		}
+		return eventSource{
+			kind:  trace.SpanKindServer,
+			attrs: attrs,
+		}, nil
+	}
+
+	attrs := pubsubAttributes("AmazonEventBridge", event.Source, 1)
+	if event.ID != "" {
+		attrs = append(attrs, semconv.MessagingMessageID(event.ID))
+	}
+
+	return eventSource{
+		kind:    trace.SpanKindConsumer,
+		attrs:   attrs,
+		records: []eventRecord{{carrier: detailCarrier(event.Detail)}},
+	}, nil
+}
+
+// detailCarrier returns the string fields at the top level of the detail of
+// an EventBridge event, where producers can store their trace context.
+func detailCarrier(detail json.RawMessage) propagation.TextMapCarrier {
+	carrier := propagation.MapCarrier{}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(detail, &fields); err != nil {
+		return carrier
+	}
+	for k, v := range fields {
+		if s, ok := v.(string); ok {
+			carrier[k] = s
+		}
+	}
+	return carrier
+}
+
+// pubsubAttributes returns the attributes of messaging events.
+func pubsubAttributes(system, destination string, count int) []attribute.KeyValue {
+	return append([]attribute.KeyValue{semconv.FaaSTriggerPubsub}, messagingAttributes(system, destination, count)...)
+}
+
+// messagingAttributes returns the attributes common to all messages.
+func messagingAttributes(system, destination string, count int) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		semconv.MessagingSystem(system),
+		semconv.MessagingOperationProcess,
+	}
+	if destination != "" {
+		attrs = append(attrs, semconv.MessagingDestinationName(destination))
+	}
+	if count > 1 {
+		attrs = append(attrs, semconv.MessagingBatchMessageCount(count))
+	}
+	return attrs
+}
+
+// arnResource returns the resource part of arn, i.e. everything after the
+// fifth colon, or an empty string if arn is not a valid ARN.
+func arnResource(arn string) string {
+	parts := strings.SplitN(arn, ":", 6)
+	if len(parts) < 6 || parts[0] != "arn" {
+		return ""
+	}
+	return parts[5]
+}
@@ -0,0 +1,299 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otellambda
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const testTraceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+func TestDetectEventSource(t *testing.T) {
+	tests := []struct {
+		name    string
+		event   string
+		kind    trace.SpanKind
+		attrs   []attribute.KeyValue
+		records int
+	}{
+		{
+			name: "API Gateway REST API",
+			event: `{
+				"resource": "/items/{id}",
+				"path": "/items/42",
+				"httpMethod": "GET",
+				"headers": {"Host": "example.com", "X-Forwarded-Proto": "https", "traceparent": "` + testTraceparent + `"},
+				"queryStringParameters": {"q": "a"},
+				"requestContext": {"domainName": "api.example.com", "identity": {"sourceIp": "192.0.2.1", "userAgent": "curl"}}
+			}`,
+			kind: trace.SpanKindServer,
+			attrs: []attribute.KeyValue{
+				semconv.FaaSTriggerHTTP,
+				semconv.HTTPMethodKey.String("GET"),
+				semconv.HTTPTargetKey.String("/items/42?q=a"),
+				semconv.HTTPSchemeKey.String("https"),
+				semconv.HTTPRoute("/items/{id}"),
+				httpClientIPKey.String("192.0.2.1"),
+				semconv.UserAgentOriginal("curl"),
+				semconv.NetHostNameKey.String("api.example.com"),
+			},
+			records: 1,
+		},
+		{
+			name: "API Gateway HTTP API",
+			event: `{
+				"version": "2.0",
+				"routeKey": "POST /items",
+				"rawPath": "/items",
+				"rawQueryString": "a=1&b=2",
+				"headers": {"user-agent": "curl", "x-forwarded-for": "192.0.2.1, 198.51.100.1"},
+				"requestContext": {"domainName": "api.example.com", "http": {"method": "POST", "path": "/items"}}
+			}`,
+			kind: trace.SpanKindServer,
+			attrs: []attribute.KeyValue{
+				semconv.FaaSTriggerHTTP,
+				semconv.HTTPMethodKey.String("POST"),
+				semconv.HTTPTargetKey.String("/items?a=1&b=2"),
+				semconv.HTTPRoute("/items"),
+				httpClientIPKey.String("192.0.2.1"),
+				semconv.UserAgentOriginal("curl"),
+				semconv.NetHostNameKey.String("api.example.com"),
+			},
+			records: 1,
+		},
+		{
+			name: "Application Load Balancer",
+			event: `{
+				"httpMethod": "GET",
+				"path": "/",
+				"multiValueHeaders": {"host": ["lb.example.com"], "x-forwarded-proto": ["http"]},
+				"requestContext": {"elb": {"targetGroupArn": "arn:aws:elasticloadbalancing:us-east-1:123456789012:targetgroup/tg/1"}}
+			}`,
+			kind: trace.SpanKindServer,
+			attrs: []attribute.KeyValue{
+				semconv.FaaSTriggerHTTP,
+				semconv.HTTPMethodKey.String("GET"),
+				semconv.HTTPTargetKey.String("/"),
+				semconv.HTTPSchemeKey.String("http"),
+				semconv.NetHostNameKey.String("lb.example.com"),
+			},
+			records: 1,
+		},
+		{
+			name: "SQS",
+			event: `{"Records": [{
+				"messageId": "m1",
+				"eventSource": "aws:sqs",
+				"eventSourceARN": "arn:aws:sqs:us-east-1:123456789012:queue"
+			}]}`,
+			kind: trace.SpanKindConsumer,
+			attrs: []attribute.KeyValue{
+				semconv.FaaSTriggerPubsub,
+				semconv.MessagingSystem("AmazonSQS"),
+				semconv.MessagingOperationProcess,
+				semconv.MessagingDestinationName("queue"),
+				semconv.MessagingMessageID("m1"),
+			},
+			records: 1,
+		},
+		{
+			name: "SNS",
+			event: `{"Records": [
+				{"EventSource": "aws:sns", "Sns": {"MessageId": "m1", "TopicArn": "arn:aws:sns:us-east-1:123456789012:topic"}},
+				{"EventSource": "aws:sns", "Sns": {"MessageId": "m2", "TopicArn": "arn:aws:sns:us-east-1:123456789012:topic"}}
+			]}`,
+			kind: trace.SpanKindConsumer,
+			attrs: []attribute.KeyValue{
+				semconv.FaaSTriggerPubsub,
+				semconv.MessagingSystem("AmazonSNS"),
+				semconv.MessagingOperationProcess,
+				semconv.MessagingDestinationName("topic"),
+				semconv.MessagingBatchMessageCount(2),
+			},
+			records: 2,
+		},
+		{
+			name: "Kinesis",
+			event: `{"Records": [{
+				"eventID": "shardId-000000000000:1",
+				"eventSource": "aws:kinesis",
+				"eventSourceARN": "arn:aws:kinesis:us-east-1:123456789012:stream/stream",
+				"kinesis": {"data": "aGVsbG8="}
+			}]}`,
+			kind: trace.SpanKindConsumer,
+			attrs: []attribute.KeyValue{
+				semconv.FaaSTriggerPubsub,
+				semconv.MessagingSystem("AmazonKinesis"),
+				semconv.MessagingOperationProcess,
+				semconv.MessagingDestinationName("stream"),
+				semconv.MessagingMessageID("shardId-000000000000:1"),
+			},
+			records: 1,
+		},
+		{
+			name:  "EventBridge",
+			event: `{"id": "e1", "detail-type": "Order Placed", "source": "com.example.orders", "detail": {"traceparent": "` + testTraceparent + `"}}`,
+			kind:  trace.SpanKindConsumer,
+			attrs: []attribute.KeyValue{
+				semconv.FaaSTriggerPubsub,
+				semconv.MessagingSystem("AmazonEventBridge"),
+				semconv.MessagingOperationProcess,
+				semconv.MessagingDestinationName("com.example.orders"),
+				semconv.MessagingMessageID("e1"),
+			},
+			records: 1,
+		},
+		{
+			name:  "EventBridge schedule",
+			event: `{"id": "e1", "detail-type": "Scheduled Event", "source": "aws.events", "time": "2023-01-01T00:00:00Z", "detail": {}}`,
+			kind:  trace.SpanKindServer,
+			attrs: []attribute.KeyValue{
+				semconv.FaaSTriggerTimer,
+				semconv.FaaSTime("2023-01-01T00:00:00Z"),
+			},
+		},
+		{
+			name:  "unknown",
+			event: `{"key": "value"}`,
+			kind:  trace.SpanKindServer,
+			attrs: []attribute.KeyValue{semconv.FaaSTriggerOther},
+		},
+		{
+			name:  "not JSON",
+			event: ``,
+			kind:  trace.SpanKindServer,
+			attrs: []attribute.KeyValue{semconv.FaaSTriggerOther},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			src := detectEventSource([]byte(tc.event), jsonKinesisRecordToCarrier)
+			assert.Equal(t, tc.kind, src.kind)
+			assert.Equal(t, tc.attrs, src.attrs)
+			assert.Len(t, src.records, tc.records)
+		})
+	}
+}
+
+func TestDetectEventSourceCarriers(t *testing.T) {
+	tests := []struct {
+		name  string
+		event string
+	}{
+		{
+			name:  "headers",
+			event: `{"httpMethod": "GET", "headers": {"Traceparent": "` + testTraceparent + `"}, "requestContext": {}}`,
+		},
+		{
+			name:  "multi-value headers",
+			event: `{"httpMethod": "GET", "multiValueHeaders": {"traceparent": ["` + testTraceparent + `"]}, "requestContext": {}}`,
+		},
+		{
+			name: "SQS message attributes",
+			event: `{"Records": [{"eventSource": "aws:sqs", "messageAttributes": {
+				"traceparent": {"dataType": "String", "stringValue": "` + testTraceparent + `"}
+			}}]}`,
+		},
+		{
+			name: "SNS message attributes",
+			event: `{"Records": [{"EventSource": "aws:sns", "Sns": {"MessageAttributes": {
+				"traceparent": {"Type": "String", "Value": "` + testTraceparent + `"}
+			}}}]}`,
+		},
+		{
+			// {"traceContext":{"traceparent":"..."},"data":"aGVsbG8="} encoded in base64.
+			name:  "Kinesis envelope",
+			event: `{"Records": [{"eventSource": "aws:kinesis", "kinesis": {"data": "eyJ0cmFjZUNvbnRleHQiOnsidHJhY2VwYXJlbnQiOiIwMC00YmY5MmYzNTc3YjM0ZGE2YTNjZTkyOWQwZTBlNDczNi0wMGYwNjdhYTBiYTkwMmI3LTAxIn0sImRhdGEiOiJhR1ZzYkc4PSJ9"}}]}`,
+		},
+		{
+			name:  "EventBridge detail",
+			event: `{"detail-type": "Order Placed", "detail": {"traceparent": "` + testTraceparent + `", "count": 1}}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			src := detectEventSource([]byte(tc.event), jsonKinesisRecordToCarrier)
+			if assert.Len(t, src.records, 1) {
+				assert.Equal(t, testTraceparent, src.records[0].carrier.Get("traceparent"))
+			}
+		})
+	}
+}
+
+func TestKinesisRecordCarrierNotWrapped(t *testing.T) {
+	assert.Empty(t, jsonKinesisRecordToCarrier([]byte("hello")).Keys())
+}
+
+func TestDetectEventSourceKinesisRecordToCarrier(t *testing.T) {
+	// The data of the record is "hello" encoded in base64.
+	event := `{"Records": [{"eventSource": "aws:kinesis", "kinesis": {"data": "aGVsbG8="}}]}`
+	var data []byte
+	custom := func(d []byte) propagation.TextMapCarrier {
+		data = d
+		return propagation.MapCarrier{"traceparent": testTraceparent}
+	}
+
+	src := detectEventSource([]byte(event), custom)
+	assert.Equal(t, []byte("hello"), data)
+	if assert.Len(t, src.records, 1) {
+		assert.Equal(t, testTraceparent, src.records[0].carrier.Get("traceparent"))
+	}
+}
+
+func TestMessageAttributeCarriers(t *testing.T) {
+	sqsCarrier := SQSMessageAttributeCarrier{}
+	sqsCarrier.Set("key", "value")
+	assert.Equal(t, "value", sqsCarrier.Get("key"))
+	assert.Equal(t, "", sqsCarrier.Get("missing"))
+	assert.Equal(t, []string{"key"}, sqsCarrier.Keys())
+
+	snsCarrier := SNSMessageAttributeCarrier{"binary": map[string]interface{}{"Type": "Binary"}}
+	snsCarrier.Set("key", "value")
+	assert.Equal(t, "value", snsCarrier.Get("key"))
+	assert.Equal(t, "", snsCarrier.Get("binary"))
+	assert.ElementsMatch(t, []string{"key", "binary"}, snsCarrier.Keys())
+
+	headerCarrier := HeaderMapCarrier{}
+	headerCarrier.Set("Key", "value")
+	assert.Equal(t, "value", headerCarrier.Get("key"))
+	assert.Equal(t, []string{"Key"}, headerCarrier.Keys())
+}
+
+func TestDetectEventSourceRecords(t *testing.T) {
+	src := detectEventSource([]byte(`{"Records": [
+		{"eventID": "shardId-000000000000:1", "eventSource": "aws:kinesis", "eventSourceARN": "arn:aws:kinesis:us-east-1:123456789012:stream/stream", "kinesis": {"sequenceNumber": "1"}},
+		{"eventID": "shardId-000000000000:2", "eventSource": "aws:kinesis", "eventSourceARN": "arn:aws:kinesis:us-east-1:123456789012:stream/stream", "kinesis": {"sequenceNumber": "2"}}
+	]}`), jsonKinesisRecordToCarrier)
+
+	assert.Equal(t, "stream", src.destination)
+	if assert.Len(t, src.records, 2) {
+		assert.Equal(t, "2", src.records[1].itemIdentifier)
+		assert.Equal(t, []attribute.KeyValue{
+			semconv.MessagingSystem("AmazonKinesis"),
+			semconv.MessagingOperationProcess,
+			semconv.MessagingDestinationName("stream"),
+			semconv.MessagingMessageID("shardId-000000000000:2"),
+		}, src.records[1].attrs)
+	}
+}
@@ -16,14 +16,21 @@ package otellambda // import "go.opentelemetry.io/contrib/instrumentation/github
 
 import (
 	"context"
+	"errors"
 	"log"
 	"os"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/aws/aws-lambda-go/lambdacontext"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
 	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
 	"go.opentelemetry.io/otel/trace"
 )
@@ -33,42 +40,145 @@ const (
 	ScopeName = "go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-lambda-go/otellambda"
 )
 
+// timeoutMargin is the time before the deadline of an invocation at which
+// the invocation is considered to have timed out. It leaves time to end and
+// flush the telemetry before the Lambda runtime stops the function.
+const timeoutMargin = 50 * time.Millisecond
+
 var errorLogger = log.New(log.Writer(), "OTel Lambda Error: ", 0)
 
+// errTimeout is the error of the invocations which timed out.
+var errTimeout = errors.New("function timed out")
+
 type instrumentor struct {
 	configuration config
-	resAttrs      []attribute.KeyValue
 	tracer        trace.Tracer
+	measures      measures
+	// invoked reports whether the function was already invoked, the first
+	// invocation being a cold start.
+	invoked *atomic.Bool
+	// setup runs the Setup of the configuration on the first invocation.
+	setup sync.Once
 }
 
-func newInstrumentor(opts ...Option) instrumentor {
+func newInstrumentor(opts ...Option) *instrumentor {
 	cfg := config{
 		TracerProvider: otel.GetTracerProvider(),
+		MeterProvider:  otel.GetMeterProvider(),
 		Flusher:        &noopFlusher{},
 		EventToCarrier: emptyEventToCarrier,
 		Propagator:     otel.GetTextMapPropagator(),
+
+		KinesisRecordToCarrier: jsonKinesisRecordToCarrier,
 	}
 	for _, opt := range opts {
 		opt.apply(&cfg)
 	}
 
-	return instrumentor{
+	i := &instrumentor{
 		configuration: cfg,
-		tracer:        cfg.TracerProvider.Tracer(ScopeName, trace.WithInstrumentationVersion(Version())),
-		resAttrs:      []attribute.KeyValue{},
+		invoked:       &atomic.Bool{},
+	}
+	i.createInstruments()
+	return i
+}
+
+// createInstruments creates the tracer and the instruments from the
+// providers of the configuration.
+func (i *instrumentor) createInstruments() {
+	i.tracer = i.configuration.TracerProvider.Tracer(ScopeName, trace.WithInstrumentationVersion(Version()))
+	i.measures = newMeasures(i.configuration.MeterProvider.Meter(ScopeName,
+		metric.WithInstrumentationVersion(Version()),
+		metric.WithSchemaURL(semconv.SchemaURL)))
+}
+
+// runSetup applies the options returned by the Setup of the configuration,
+// on the first invocation only.
+func (i *instrumentor) runSetup(ctx context.Context) {
+	if i.configuration.Setup == nil {
+		return
 	}
+	i.setup.Do(func() {
+		for _, opt := range i.configuration.Setup(ctx) {
+			opt.apply(&i.configuration)
+		}
+		i.createInstruments()
+	})
+}
+
+// invocation holds the state of an instrumented invocation.
+type invocation struct {
+	span trace.Span
+	// records are the process spans of the records of a batch, see
+	// WithRecordSpans, or nil.
+	records *recordSpans
+	// httpResponse reports whether the invocation was triggered by an HTTP
+	// event, whose response holds an HTTP status code.
+	httpResponse bool
+
+	start       time.Time
+	metricAttrs []attribute.KeyValue
+	// timers end the invocation when it times out and warn when its
+	// remaining time is low.
+	timers []*time.Timer
+	// end ensures the invocation is ended once, either when the handler
+	// returns or when the invocation times out.
+	end sync.Once
+}
+
+// inspectsResponse returns whether the response of the handler is used to
+// end the invocation.
+func (inv *invocation) inspectsResponse() bool {
+	return inv.records != nil || inv.httpResponse
+}
+
+func (inv *invocation) duration() time.Duration {
+	return time.Since(inv.start)
 }
 
 // Logic to start OTel Tracing.
-func (i *instrumentor) tracingBegin(ctx context.Context, eventJSON []byte) (context.Context, trace.Span) {
+func (i *instrumentor) tracingBegin(ctx context.Context, eventJSON []byte) (context.Context, *invocation) {
+	i.runSetup(ctx)
+
 	// Add trace id to context
 	mc := i.configuration.EventToCarrier(eventJSON)
 	ctx = i.configuration.Propagator.Extract(ctx, mc)
 
-	var span trace.Span
+	inv := &invocation{start: time.Now()}
 	spanName := os.Getenv("AWS_LAMBDA_FUNCTION_NAME")
 
 	var attributes []attribute.KeyValue
+	spanOpts := []trace.SpanStartOption{trace.WithSpanKind(trace.SpanKindServer)}
+	var src eventSource
+	if i.configuration.EventSourceDetection {
+		src = detectEventSource(eventJSON, i.configuration.KinesisRecordToCarrier)
+		attributes = append(attributes, src.attrs...)
+		for _, attr := range src.attrs {
+			if attr.Key == semconv.FaaSTriggerKey {
+				inv.metricAttrs = append(inv.metricAttrs, attr)
+				inv.httpResponse = attr == semconv.FaaSTriggerHTTP
+			}
+		}
+		spanOpts = []trace.SpanStartOption{trace.WithSpanKind(src.kind)}
+
+		switch len(src.records) {
+		case 0:
+		case 1:
+			// The trace context of the event takes precedence over the
+			// one returned by the EventToCarrier.
+			if i.remoteSpanContext(src.records[0].carrier).IsValid() {
+				ctx = i.configuration.Propagator.Extract(ctx, src.records[0].carrier)
+			}
+		default:
+			var links []trace.Link
+			for _, r := range src.records {
+				if sc := i.remoteSpanContext(r.carrier); sc.IsValid() {
+					links = append(links, trace.Link{SpanContext: sc})
+				}
+			}
+			spanOpts = append(spanOpts, trace.WithLinks(links...))
+		}
+	}
 	lc, ok := lambdacontext.FromContext(ctx)
 	if !ok {
 		errorLogger.Println("failed to load lambda context from context, ensure tracing enabled in Lambda")
@@ -77,33 +187,110 @@ func (i *instrumentor) tracingBegin(ctx context.Context, eventJSON []byte) (cont
 		ctxRequestID := lc.AwsRequestID
 		attributes = append(attributes, semconv.FaaSInvocationID(ctxRequestID))
 
-		// Some resource attrs added as span attrs because lambda
-		// resource detectors are created before a lambda
-		// invocation and therefore lack lambdacontext.
-		// Create these attrs upon first invocation
-		if len(i.resAttrs) == 0 {
-			ctxFunctionArn := lc.InvokedFunctionArn
-			attributes = append(attributes, semconv.AWSLambdaInvokedARN(ctxFunctionArn))
+		ctxFunctionArn := lc.InvokedFunctionArn
+		attributes = append(attributes, semconv.AWSLambdaInvokedARN(ctxFunctionArn))
+
+		// The account is a resource attribute, added as span attribute
+		// because lambda resource detectors are run before a lambda
+		// invocation and therefore lack lambdacontext. With a Setup, the
+		// resource can be detected from the first invocation instead.
+		if i.configuration.Setup == nil {
 			arnParts := strings.Split(ctxFunctionArn, ":")
 			if len(arnParts) >= 5 {
 				attributes = append(attributes, semconv.CloudAccountID(arnParts[4]))
 			}
 		}
-		attributes = append(attributes, i.resAttrs...)
 	}
 
-	ctx, span = i.tracer.Start(ctx, spanName, trace.WithSpanKind(trace.SpanKindServer), trace.WithAttributes(attributes...))
+	if !i.invoked.Swap(true) {
+		attributes = append(attributes, semconv.FaaSColdstart(true))
+		i.measures.coldStarts.Add(ctx, 1, metric.WithAttributes(inv.metricAttrs...))
+	}
+
+	spanOpts = append(spanOpts, trace.WithAttributes(attributes...))
+	ctx, inv.span = i.tracer.Start(ctx, spanName, spanOpts...)
+
+	if i.configuration.RecordSpans && src.destination != "" {
+		inv.records = i.newRecordSpans(src)
+		ctx = context.WithValue(ctx, recordSpansKey{}, inv.records)
+	}
+	i.startTimers(ctx, inv)
+
+	return ctx, inv
+}
+
+// startTimers starts the timers of inv if ctx has a deadline. The first one
+// adds an event to the span of the invocation when its remaining time drops
+// below the RemainingTimeWarning threshold, the second one ends the
+// invocation as timed out shortly before its deadline.
+func (i *instrumentor) startTimers(ctx context.Context, inv *invocation) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return
+	}
+
+	if threshold := i.configuration.RemainingTimeWarning; threshold > 0 {
+		inv.timers = append(inv.timers, time.AfterFunc(time.Until(deadline.Add(-threshold)), func() {
+			inv.span.AddEvent("remaining time low", trace.WithAttributes(
+				attribute.Int64("aws.lambda.remaining_time_ms", time.Until(deadline).Milliseconds()),
+			))
+		}))
+	}
 
-	return ctx, span
+	if d := time.Until(deadline.Add(-timeoutMargin)); d > 0 {
+		inv.timers = append(inv.timers, time.AfterFunc(d, func() {
+			// The context of the invocation is about to be canceled.
+			ctx, cancel := context.WithTimeout(context.Background(), timeoutMargin)
+			defer cancel()
+			i.end(ctx, inv, nil, errTimeout)
+		}))
+	}
 }
 
-// Logic to wrap up OTel Tracing.
-func (i *instrumentor) tracingEnd(ctx context.Context, span trace.Span) {
-	span.End()
+// remoteSpanContext returns the span context extracted from carrier.
+func (i *instrumentor) remoteSpanContext(carrier propagation.TextMapCarrier) trace.SpanContext {
+	return trace.SpanContextFromContext(i.configuration.Propagator.Extract(context.Background(), carrier))
+}
 
-	// force flush any tracing data since lambda may freeze
-	err := i.configuration.Flusher.ForceFlush(ctx)
-	if err != nil {
-		errorLogger.Println("failed to force a flush, lambda may freeze before instrumentation exported: ", err)
+// Logic to wrap up OTel Tracing. The response is the JSON encoded response
+// of the handler, it is only provided when the invocation inspects it.
+func (i *instrumentor) tracingEnd(ctx context.Context, inv *invocation, response []byte, err error) {
+	for _, t := range inv.timers {
+		t.Stop()
 	}
+	i.end(ctx, inv, response, err)
+}
+
+// end ends the spans of inv, records its measures and flushes the telemetry.
+// Only the first call has an effect. An err of errTimeout reports that the
+// invocation timed out.
+func (i *instrumentor) end(ctx context.Context, inv *invocation, response []byte, err error) {
+	inv.end.Do(func() {
+		timedOut := errors.Is(err, errTimeout)
+		if timedOut {
+			inv.span.SetStatus(codes.Error, err.Error())
+		}
+		if inv.httpResponse && err == nil {
+			if r, ok := jsonHTTPResponse(response); ok {
+				setHTTPResponse(inv.span, r)
+			}
+		}
+		if inv.records != nil {
+			inv.records.end(trace.ContextWithSpan(context.Background(), inv.span), response, err)
+		}
+		inv.span.End()
+		i.measures.record(ctx, inv, err, timedOut)
+
+		// force flush any tracing data since lambda may freeze
+		if flushErr := i.configuration.Flusher.ForceFlush(ctx); flushErr != nil {
+			errorLogger.Println("failed to force a flush, lambda may freeze before instrumentation exported: ", flushErr)
+		}
+		// the MeterProvider is flushed as well if it supports it, e.g. the
+		// MeterProvider of the SDK
+		if f, ok := i.configuration.MeterProvider.(Flusher); ok {
+			if flushErr := f.ForceFlush(ctx); flushErr != nil {
+				errorLogger.Println("failed to force a flush of metrics, lambda may freeze before instrumentation exported: ", flushErr)
+			}
+		}
+	})
 }
@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otellambda // import "go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-lambda-go/otellambda"
+
+import (
+	"context"
+	"runtime/metrics"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// memoryMetric is the runtime metric measuring the memory mapped by the Go
+// runtime.
+const memoryMetric = "/memory/classes/total:bytes"
+
+// measures holds the instruments of the invocations.
+type measures struct {
+	duration    metric.Float64Histogram
+	invocations metric.Int64Counter
+	errors      metric.Int64Counter
+	timeouts    metric.Int64Counter
+	coldStarts  metric.Int64Counter
+	memUsage    metric.Int64Histogram
+}
+
+func newMeasures(meter metric.Meter) measures {
+	var (
+		m   measures
+		err error
+	)
+	m.duration, err = meter.Float64Histogram("faas.invoke_duration",
+		metric.WithDescription("Measures the duration of the function's logic execution."),
+		metric.WithUnit("s"))
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	m.invocations, err = meter.Int64Counter("faas.invocations",
+		metric.WithDescription("Measures the number of invocations."),
+		metric.WithUnit("{invocation}"))
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	m.errors, err = meter.Int64Counter("faas.errors",
+		metric.WithDescription("Measures the number of invocation errors."),
+		metric.WithUnit("{error}"))
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	m.timeouts, err = meter.Int64Counter("faas.timeouts",
+		metric.WithDescription("Measures the number of invocation timeouts."),
+		metric.WithUnit("{timeout}"))
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	m.coldStarts, err = meter.Int64Counter("faas.coldstarts",
+		metric.WithDescription("Measures the number of invocation cold starts."),
+		metric.WithUnit("{coldstart}"))
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	m.memUsage, err = meter.Int64Histogram("faas.mem_usage",
+		metric.WithDescription("Measures the memory mapped by the Go runtime at the end of invocations."),
+		metric.WithUnit("By"))
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	return m
+}
+
+// record records the measures of an ended invocation.
+func (m measures) record(ctx context.Context, inv *invocation, err error, timedOut bool) {
+	opt := metric.WithAttributes(inv.metricAttrs...)
+	m.duration.Record(ctx, inv.duration().Seconds(), opt)
+	m.invocations.Add(ctx, 1, opt)
+	switch {
+	case timedOut:
+		m.timeouts.Add(ctx, 1, opt)
+	case err != nil:
+		m.errors.Add(ctx, 1, opt)
+	}
+	if mem, ok := memoryUsage(); ok {
+		m.memUsage.Record(ctx, mem, opt)
+	}
+}
+
+// memoryUsage returns the memory mapped by the Go runtime, in bytes.
+func memoryUsage() (int64, bool) {
+	sample := []metrics.Sample{{Name: memoryMetric}}
+	metrics.Read(sample)
+	if sample[0].Value.Kind() != metrics.KindUint64 {
+		return 0, false
+	}
+	return int64(sample[0].Value.Uint64()), true
+}
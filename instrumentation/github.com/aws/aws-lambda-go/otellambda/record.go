@@ -0,0 +1,162 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otellambda // import "go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-lambda-go/otellambda"
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// recordSpansKey is the context key of the recordSpans of an invocation.
+type recordSpansKey struct{}
+
+// recordSpans are the process spans of the records of a batch.
+type recordSpans struct {
+	tracer trace.Tracer
+	name   string
+	// start is the start time of the spans not started with StartRecord.
+	start time.Time
+
+	mu      sync.Mutex
+	records []*recordSpan
+	// ended reports whether the invocation ended, after which no span is
+	// started anymore.
+	ended bool
+}
+
+// recordSpan is the process span of a record of a batch.
+type recordSpan struct {
+	itemIdentifier string
+	opts           []trace.SpanStartOption
+	// span is the process span of the record, started by StartRecord or
+	// when the invocation ends.
+	span trace.Span
+	// end is the time the processing of the record ended, if reported.
+	end time.Time
+}
+
+// newRecordSpans returns the process spans of the records of src, linked to
+// the trace context of their record. The spans are not started yet.
+func (i *instrumentor) newRecordSpans(src eventSource) *recordSpans {
+	rs := &recordSpans{
+		tracer:  i.tracer,
+		name:    src.destination + " process",
+		start:   time.Now(),
+		records: make([]*recordSpan, len(src.records)),
+	}
+	for n, r := range src.records {
+		opts := []trace.SpanStartOption{
+			trace.WithSpanKind(trace.SpanKindConsumer),
+			trace.WithAttributes(r.attrs...),
+		}
+		if sc := i.remoteSpanContext(r.carrier); sc.IsValid() {
+			opts = append(opts, trace.WithLinks(trace.Link{SpanContext: sc}))
+		}
+		rs.records[n] = &recordSpan{itemIdentifier: r.itemIdentifier, opts: opts}
+	}
+	return rs
+}
+
+// StartRecord starts the process span of the record of the batch being
+// processed whose identifier is itemIdentifier, the message ID of SQS
+// messages or the sequence number of Kinesis records as used in
+// batchItemFailures. It returns a copy of ctx holding the span, to use while
+// processing the record, and a function to call when the processing ends.
+//
+// The span is only ended with the invocation, once its status is known from
+// the response of the handler, but with the end time reported by the
+// returned function. The spans of the records that are not started with
+// StartRecord last as long as the invocation.
+//
+// ctx must be the context of the invocation, or derived from it, with
+// WithRecordSpans set. Otherwise, or if no record of the batch has the
+// identifier itemIdentifier, ctx is returned unchanged.
+func StartRecord(ctx context.Context, itemIdentifier string) (context.Context, func()) {
+	rs, ok := ctx.Value(recordSpansKey{}).(*recordSpans)
+	if !ok {
+		return ctx, func() {}
+	}
+
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	if rs.ended {
+		return ctx, func() {}
+	}
+	for _, r := range rs.records {
+		if r.itemIdentifier != itemIdentifier || r.span != nil {
+			continue
+		}
+		r := r
+		ctx, r.span = rs.tracer.Start(ctx, rs.name, r.opts...)
+		return ctx, func() {
+			rs.mu.Lock()
+			defer rs.mu.Unlock()
+			if r.end.IsZero() {
+				r.end = time.Now()
+			}
+		}
+	}
+	return ctx, func() {}
+}
+
+// batchResponse is the response of a function reporting partial batch
+// failures.
+type batchResponse struct {
+	BatchItemFailures []struct {
+		ItemIdentifier string `json:"itemIdentifier"`
+	} `json:"batchItemFailures"`
+}
+
+// end ends the process spans of the records. The spans that were not
+// started with StartRecord are started as children of the span in ctx, at
+// the time the recordSpans were created. If the handler returned an error,
+// the whole batch failed and all spans are marked as failed. Otherwise, the
+// spans of the records listed in the batchItemFailures of the response are.
+func (rs *recordSpans) end(ctx context.Context, response []byte, err error) {
+	failed := map[string]bool{}
+	if err == nil && len(response) > 0 {
+		var resp batchResponse
+		if jsonErr := json.Unmarshal(response, &resp); jsonErr == nil {
+			for _, f := range resp.BatchItemFailures {
+				failed[f.ItemIdentifier] = true
+			}
+		}
+	}
+
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	rs.ended = true
+	for _, r := range rs.records {
+		if r.span == nil {
+			_, r.span = rs.tracer.Start(ctx, rs.name, append(r.opts, trace.WithTimestamp(rs.start))...)
+		}
+		switch {
+		case err != nil:
+			r.span.SetStatus(codes.Error, err.Error())
+		case failed[r.itemIdentifier]:
+			r.span.SetStatus(codes.Error, "batch item failure")
+		}
+		if r.end.IsZero() {
+			r.span.End()
+		} else {
+			r.span.End(trace.WithTimestamp(r.end))
+		}
+	}
+}
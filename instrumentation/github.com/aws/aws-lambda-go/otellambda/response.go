@@ -0,0 +1,110 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otellambda // import "go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-lambda-go/otellambda"
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// httpResponse holds the fields shared by the responses of functions invoked
+// by API Gateway REST and HTTP APIs and Application Load Balancers.
+type httpResponse struct {
+	StatusCode      int    `json:"statusCode"`
+	Body            string `json:"body"`
+	IsBase64Encoded bool   `json:"isBase64Encoded"`
+}
+
+// typedHTTPResponse returns the httpResponse of v if it is one of the known
+// HTTP response types of the events package.
+func typedHTTPResponse(v interface{}) (httpResponse, bool) {
+	switch r := v.(type) {
+	case events.APIGatewayProxyResponse:
+		return httpResponse{StatusCode: r.StatusCode, Body: r.Body, IsBase64Encoded: r.IsBase64Encoded}, true
+	case *events.APIGatewayProxyResponse:
+		if r != nil {
+			return httpResponse{StatusCode: r.StatusCode, Body: r.Body, IsBase64Encoded: r.IsBase64Encoded}, true
+		}
+	case events.APIGatewayV2HTTPResponse:
+		return httpResponse{StatusCode: r.StatusCode, Body: r.Body, IsBase64Encoded: r.IsBase64Encoded}, true
+	case *events.APIGatewayV2HTTPResponse:
+		if r != nil {
+			return httpResponse{StatusCode: r.StatusCode, Body: r.Body, IsBase64Encoded: r.IsBase64Encoded}, true
+		}
+	case events.ALBTargetGroupResponse:
+		return httpResponse{StatusCode: r.StatusCode, Body: r.Body, IsBase64Encoded: r.IsBase64Encoded}, true
+	case *events.ALBTargetGroupResponse:
+		if r != nil {
+			return httpResponse{StatusCode: r.StatusCode, Body: r.Body, IsBase64Encoded: r.IsBase64Encoded}, true
+		}
+	}
+	return httpResponse{}, false
+}
+
+// jsonHTTPResponse returns the httpResponse of the JSON encoded response of a
+// function invoked by an HTTP event.
+func jsonHTTPResponse(response []byte) (httpResponse, bool) {
+	var r httpResponse
+	if err := json.Unmarshal(response, &r); err != nil || r.StatusCode == 0 {
+		return httpResponse{}, false
+	}
+	return r, true
+}
+
+// bodySize returns the size of the decoded body of r.
+func (r httpResponse) bodySize() int {
+	if r.IsBase64Encoded {
+		tail := r.Body
+		if len(tail) > 2 {
+			tail = tail[len(tail)-2:]
+		}
+		return base64.StdEncoding.DecodedLen(len(r.Body)) - strings.Count(tail, "=")
+	}
+	return len(r.Body)
+}
+
+// setHTTPResponse sets the status code and the body size of r on span, as
+// well as the span status. Like for otelhttp handlers, 5xx status codes are
+// errors, and the http.status_code and http.response_content_length
+// attributes are used.
+func setHTTPResponse(span trace.Span, r httpResponse) {
+	attrs := []attribute.KeyValue{semconv.HTTPStatusCode(r.StatusCode)}
+	if size := r.bodySize(); size > 0 {
+		attrs = append(attrs, semconv.HTTPResponseContentLength(size))
+	}
+	span.SetAttributes(attrs...)
+	span.SetStatus(httpServerStatus(r.StatusCode))
+}
+
+// httpServerStatus returns the span status of an HTTP server response with
+// the status code code.
+func httpServerStatus(code int) (codes.Code, string) {
+	if code < 100 || code >= 600 {
+		return codes.Error, fmt.Sprintf("Invalid HTTP status code %d", code)
+	}
+	if code >= 500 {
+		return codes.Error, ""
+	}
+	return codes.Unset, ""
+}
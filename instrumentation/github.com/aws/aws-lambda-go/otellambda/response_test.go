@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otellambda
+
+import (
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/codes"
+)
+
+func TestTypedHTTPResponse(t *testing.T) {
+	want := httpResponse{StatusCode: 503, Body: "unavailable"}
+	for _, v := range []interface{}{
+		events.APIGatewayProxyResponse{StatusCode: 503, Body: "unavailable"},
+		&events.APIGatewayProxyResponse{StatusCode: 503, Body: "unavailable"},
+		events.APIGatewayV2HTTPResponse{StatusCode: 503, Body: "unavailable"},
+		&events.APIGatewayV2HTTPResponse{StatusCode: 503, Body: "unavailable"},
+		events.ALBTargetGroupResponse{StatusCode: 503, Body: "unavailable"},
+		&events.ALBTargetGroupResponse{StatusCode: 503, Body: "unavailable"},
+	} {
+		got, ok := typedHTTPResponse(v)
+		assert.True(t, ok)
+		assert.Equal(t, want, got)
+	}
+
+	for _, v := range []interface{}{nil, "response", (*events.APIGatewayProxyResponse)(nil)} {
+		_, ok := typedHTTPResponse(v)
+		assert.False(t, ok)
+	}
+}
+
+func TestJSONHTTPResponse(t *testing.T) {
+	got, ok := jsonHTTPResponse([]byte(`{"statusCode": 200, "body": "aGVsbG8=", "isBase64Encoded": true}`))
+	assert.True(t, ok)
+	assert.Equal(t, httpResponse{StatusCode: 200, Body: "aGVsbG8=", IsBase64Encoded: true}, got)
+
+	for _, response := range []string{``, `"response"`, `{"body": "hello"}`} {
+		_, ok := jsonHTTPResponse([]byte(response))
+		assert.False(t, ok)
+	}
+}
+
+func TestHTTPResponseBodySize(t *testing.T) {
+	assert.Equal(t, 0, httpResponse{}.bodySize())
+	assert.Equal(t, 5, httpResponse{Body: "hello"}.bodySize())
+	assert.Equal(t, 5, httpResponse{Body: "aGVsbG8=", IsBase64Encoded: true}.bodySize())
+	assert.Equal(t, 4, httpResponse{Body: "aGVsbA==", IsBase64Encoded: true}.bodySize())
+	assert.Equal(t, 6, httpResponse{Body: "aGVsbG8h", IsBase64Encoded: true}.bodySize())
+}
+
+func TestHTTPServerStatus(t *testing.T) {
+	tests := []struct {
+		code    int
+		want    codes.Code
+		message string
+	}{
+		{code: 200, want: codes.Unset},
+		{code: 404, want: codes.Unset},
+		{code: 500, want: codes.Error},
+		{code: 99, want: codes.Error, message: "Invalid HTTP status code 99"},
+		{code: 600, want: codes.Error, message: "Invalid HTTP status code 600"},
+	}
+	for _, tc := range tests {
+		got, message := httpServerStatus(tc.code)
+		assert.Equal(t, tc.want, got, tc.code)
+		assert.Equal(t, tc.message, message, tc.code)
+	}
+}
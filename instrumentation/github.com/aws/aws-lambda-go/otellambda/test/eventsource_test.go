@@ -0,0 +1,259 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambdacontext"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-lambda-go/otellambda"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	eventSourceTraceparent1 = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	eventSourceTraceparent2 = "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"
+)
+
+func TestWrapHandlerEventSourceDetection(t *testing.T) {
+	setEnvVars(t)
+	tp, memExporter := initMockTracerProvider()
+
+	wrapped := otellambda.WrapHandler(emptyHandler{},
+		otellambda.WithTracerProvider(tp),
+		otellambda.WithPropagator(propagation.TraceContext{}),
+		otellambda.WithEventSourceDetection())
+
+	ctx := lambdacontext.NewContext(context.Background(), &mockLambdaContext)
+	payload := []byte(`{"version": "2.0", "routeKey": "GET /items", "rawPath": "/items",
+		"headers": {"traceparent": "` + eventSourceTraceparent1 + `"},
+		"requestContext": {"http": {"method": "GET"}}}`)
+	_, err := wrapped.Invoke(ctx, payload)
+	require.NoError(t, err)
+
+	spans := memExporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, trace.SpanKindServer, spans[0].SpanKind)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spans[0].Parent.TraceID().String())
+	assert.Equal(t, "00f067aa0ba902b7", spans[0].Parent.SpanID().String())
+	assert.Contains(t, spans[0].Attributes, semconv.FaaSTriggerHTTP)
+	assert.Contains(t, spans[0].Attributes, semconv.HTTPRoute("/items"))
+}
+
+func TestWrapHandlerEventSourceDetectionBatch(t *testing.T) {
+	setEnvVars(t)
+	tp, memExporter := initMockTracerProvider()
+
+	wrapped := otellambda.WrapHandler(emptyHandler{},
+		otellambda.WithTracerProvider(tp),
+		otellambda.WithPropagator(propagation.TraceContext{}),
+		otellambda.WithEventSourceDetection())
+
+	ctx := lambdacontext.NewContext(context.Background(), &mockLambdaContext)
+	payload := []byte(`{"Records": [
+		{"eventSource": "aws:sqs", "eventSourceARN": "arn:aws:sqs:us-east-1:123456789012:queue", "messageAttributes": {
+			"traceparent": {"dataType": "String", "stringValue": "` + eventSourceTraceparent1 + `"}}},
+		{"eventSource": "aws:sqs", "eventSourceARN": "arn:aws:sqs:us-east-1:123456789012:queue", "messageAttributes": {
+			"traceparent": {"dataType": "String", "stringValue": "` + eventSourceTraceparent2 + `"}}},
+		{"eventSource": "aws:sqs", "eventSourceARN": "arn:aws:sqs:us-east-1:123456789012:queue"}
+	]}`)
+	_, err := wrapped.Invoke(ctx, payload)
+	require.NoError(t, err)
+
+	spans := memExporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, trace.SpanKindConsumer, spans[0].SpanKind)
+	assert.False(t, spans[0].Parent.IsValid())
+	require.Len(t, spans[0].Links, 2)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spans[0].Links[0].SpanContext.TraceID().String())
+	assert.Equal(t, "0af7651916cd43dd8448eb211c80319c", spans[0].Links[1].SpanContext.TraceID().String())
+	assert.Contains(t, spans[0].Attributes, semconv.MessagingBatchMessageCount(3))
+	assert.Contains(t, spans[0].Attributes, semconv.MessagingDestinationName("queue"))
+}
+
+type responseHandler struct {
+	response []byte
+	err      error
+}
+
+func (h responseHandler) Invoke(context.Context, []byte) ([]byte, error) {
+	return h.response, h.err
+}
+
+const recordSpansEvent = `{"Records": [
+	{"messageId": "m1", "eventSource": "aws:sqs", "eventSourceARN": "arn:aws:sqs:us-east-1:123456789012:queue", "messageAttributes": {
+		"traceparent": {"dataType": "String", "stringValue": "` + eventSourceTraceparent1 + `"}}},
+	{"messageId": "m2", "eventSource": "aws:sqs", "eventSourceARN": "arn:aws:sqs:us-east-1:123456789012:queue"}
+]}`
+
+func TestWrapHandlerRecordSpans(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler responseHandler
+		want    map[string]codes.Code
+	}{
+		{
+			name:    "success",
+			handler: responseHandler{response: []byte(`{"batchItemFailures": []}`)},
+			want:    map[string]codes.Code{"m1": codes.Unset, "m2": codes.Unset},
+		},
+		{
+			name:    "partial batch failure",
+			handler: responseHandler{response: []byte(`{"batchItemFailures": [{"itemIdentifier": "m2"}]}`)},
+			want:    map[string]codes.Code{"m1": codes.Unset, "m2": codes.Error},
+		},
+		{
+			name:    "error",
+			handler: responseHandler{err: errors.New("failed")},
+			want:    map[string]codes.Code{"m1": codes.Error, "m2": codes.Error},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			setEnvVars(t)
+			tp, memExporter := initMockTracerProvider()
+
+			wrapped := otellambda.WrapHandler(tc.handler,
+				otellambda.WithTracerProvider(tp),
+				otellambda.WithPropagator(propagation.TraceContext{}),
+				otellambda.WithRecordSpans())
+
+			ctx := lambdacontext.NewContext(context.Background(), &mockLambdaContext)
+			_, _ = wrapped.Invoke(ctx, []byte(recordSpansEvent))
+
+			assertRecordSpans(t, memExporter.GetSpans(), tc.want)
+		})
+	}
+}
+
+func TestInstrumentHandlerRecordSpans(t *testing.T) {
+	setEnvVars(t)
+	tp, memExporter := initMockTracerProvider()
+
+	handler := func(_ context.Context, event events.SQSEvent) (events.SQSEventResponse, error) {
+		return events.SQSEventResponse{
+			BatchItemFailures: []events.SQSBatchItemFailure{{ItemIdentifier: event.Records[0].MessageId}},
+		}, nil
+	}
+	wrapped := otellambda.InstrumentHandler(handler,
+		otellambda.WithTracerProvider(tp),
+		otellambda.WithPropagator(propagation.TraceContext{}),
+		otellambda.WithRecordSpans())
+
+	var payload interface{}
+	require.NoError(t, json.Unmarshal([]byte(recordSpansEvent), &payload))
+	ctx := lambdacontext.NewContext(context.Background(), &mockLambdaContext)
+	wrappedCallable := reflect.ValueOf(wrapped)
+	wrappedCallable.Call([]reflect.Value{reflect.ValueOf(ctx), reflect.ValueOf(payload)})
+
+	assertRecordSpans(t, memExporter.GetSpans(), map[string]codes.Code{"m1": codes.Error, "m2": codes.Unset})
+}
+
+func TestInstrumentHandlerStartRecord(t *testing.T) {
+	setEnvVars(t)
+	tp, memExporter := initMockTracerProvider()
+
+	var (
+		processed []trace.SpanContext
+		start     time.Time
+		end       time.Time
+	)
+	handler := func(ctx context.Context, event events.SQSEvent) error {
+		// Only the first record is timed by the handler.
+		start = time.Now()
+		ctx, done := otellambda.StartRecord(ctx, event.Records[0].MessageId)
+		processed = append(processed, trace.SpanContextFromContext(ctx))
+		time.Sleep(10 * time.Millisecond)
+		done()
+		end = time.Now()
+
+		// Unknown identifiers leave ctx unchanged.
+		unknownCtx, done := otellambda.StartRecord(ctx, "unknown")
+		done()
+		assert.Equal(t, ctx, unknownCtx)
+		return nil
+	}
+	wrapped := otellambda.InstrumentHandler(handler,
+		otellambda.WithTracerProvider(tp),
+		otellambda.WithPropagator(propagation.TraceContext{}),
+		otellambda.WithRecordSpans())
+
+	var payload interface{}
+	require.NoError(t, json.Unmarshal([]byte(recordSpansEvent), &payload))
+	ctx := lambdacontext.NewContext(context.Background(), &mockLambdaContext)
+	wrappedCallable := reflect.ValueOf(wrapped)
+	wrappedCallable.Call([]reflect.Value{reflect.ValueOf(ctx), reflect.ValueOf(payload)})
+
+	spans := memExporter.GetSpans()
+	assertRecordSpans(t, spans, map[string]codes.Code{"m1": codes.Unset, "m2": codes.Unset})
+	invocation := spans[2]
+	for _, s := range spans[:2] {
+		if s.SpanContext.Equal(processed[0]) {
+			assert.False(t, s.StartTime.Before(start), "record span starts when StartRecord is called")
+			assert.False(t, s.EndTime.After(end), "record span ends when processing ends")
+			assert.True(t, s.EndTime.Sub(s.StartTime) >= 10*time.Millisecond)
+			continue
+		}
+		// The record not started by the handler spans the invocation.
+		assert.False(t, s.StartTime.Before(invocation.StartTime))
+		assert.False(t, s.EndTime.Before(end))
+	}
+}
+
+func TestStartRecordWithoutRecordSpans(t *testing.T) {
+	ctx := context.Background()
+	got, done := otellambda.StartRecord(ctx, "m1")
+	done()
+	assert.Equal(t, ctx, got)
+}
+
+func assertRecordSpans(t *testing.T, spans tracetest.SpanStubs, want map[string]codes.Code) {
+	t.Helper()
+	require.Len(t, spans, 3)
+
+	// Record spans end before the span of the invocation.
+	invocation := spans[2]
+	assert.Equal(t, "testFunction", invocation.Name)
+
+	got := map[string]codes.Code{}
+	for _, s := range spans[:2] {
+		assert.Equal(t, "queue process", s.Name)
+		assert.Equal(t, trace.SpanKindConsumer, s.SpanKind)
+		assert.Equal(t, invocation.SpanContext.SpanID(), s.Parent.SpanID())
+		for _, attr := range s.Attributes {
+			if attr.Key == semconv.MessagingMessageIDKey {
+				got[attr.Value.AsString()] = s.Status.Code
+				if attr.Value.AsString() == "m1" && assert.Len(t, s.Links, 1) {
+					assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", s.Links[0].SpanContext.TraceID().String())
+				}
+			}
+		}
+	}
+	assert.Equal(t, want, got)
+}
@@ -0,0 +1,147 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/lambdacontext"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-lambda-go/otellambda"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+)
+
+var coldStartAttr = semconv.FaaSColdstart(true)
+
+type handlerFunc func(context.Context, []byte) ([]byte, error)
+
+func (f handlerFunc) Invoke(ctx context.Context, payload []byte) ([]byte, error) {
+	return f(ctx, payload)
+}
+
+// sums returns the values of the counters and the number of measurements of
+// the histograms of rm.
+func sums(rm metricdata.ResourceMetrics) map[string]int64 {
+	got := map[string]int64{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			switch data := m.Data.(type) {
+			case metricdata.Sum[int64]:
+				for _, dp := range data.DataPoints {
+					got[m.Name] += dp.Value
+				}
+			case metricdata.Histogram[float64]:
+				for _, dp := range data.DataPoints {
+					got[m.Name] += int64(dp.Count)
+				}
+			case metricdata.Histogram[int64]:
+				for _, dp := range data.DataPoints {
+					got[m.Name] += int64(dp.Count)
+				}
+			}
+		}
+	}
+	return got
+}
+
+func TestWrapHandlerMetrics(t *testing.T) {
+	setEnvVars(t)
+	tp, memExporter := initMockTracerProvider()
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	fail := true
+	wrapped := otellambda.WrapHandler(handlerFunc(func(context.Context, []byte) ([]byte, error) {
+		if fail {
+			return nil, errors.New("failed")
+		}
+		return nil, nil
+	}),
+		otellambda.WithTracerProvider(tp),
+		otellambda.WithMeterProvider(mp))
+
+	ctx := lambdacontext.NewContext(context.Background(), &mockLambdaContext)
+	_, err := wrapped.Invoke(ctx, nil)
+	require.Error(t, err)
+	fail = false
+	_, err = wrapped.Invoke(ctx, nil)
+	require.NoError(t, err)
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	assert.Equal(t, map[string]int64{
+		"faas.coldstarts":      1,
+		"faas.invocations":     2,
+		"faas.errors":          1,
+		"faas.invoke_duration": 2,
+		"faas.mem_usage":       2,
+	}, sums(rm))
+
+	spans := memExporter.GetSpans()
+	require.Len(t, spans, 2)
+	assert.Contains(t, spans[0].Attributes, coldStartAttr)
+	assert.NotContains(t, spans[1].Attributes, coldStartAttr)
+}
+
+func TestWrapHandlerTimeout(t *testing.T) {
+	setEnvVars(t)
+	tp, memExporter := initMockTracerProvider()
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	done := make(chan struct{})
+	wrapped := otellambda.WrapHandler(handlerFunc(func(context.Context, []byte) ([]byte, error) {
+		<-done
+		return nil, nil
+	}),
+		otellambda.WithTracerProvider(tp),
+		otellambda.WithMeterProvider(mp),
+		otellambda.WithRemainingTimeWarning(150*time.Millisecond))
+
+	ctx := lambdacontext.NewContext(context.Background(), &mockLambdaContext)
+	ctx, cancel := context.WithTimeout(ctx, 200*time.Millisecond)
+	defer cancel()
+
+	go func() {
+		// The invocation is ended as timed out before the handler returns.
+		assert.Eventually(t, func() bool { return len(memExporter.GetSpans()) == 1 }, time.Second, 10*time.Millisecond)
+		close(done)
+	}()
+	_, err := wrapped.Invoke(ctx, nil)
+	require.NoError(t, err)
+
+	spans := memExporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, codes.Error, spans[0].Status.Code)
+	assert.Equal(t, "function timed out", spans[0].Status.Description)
+	if assert.Len(t, spans[0].Events, 1) {
+		assert.Equal(t, "remaining time low", spans[0].Events[0].Name)
+	}
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	got := sums(rm)
+	assert.Equal(t, int64(1), got["faas.timeouts"])
+	assert.Equal(t, int64(1), got["faas.invocations"])
+	assert.Equal(t, int64(0), got["faas.errors"])
+}
@@ -0,0 +1,114 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambdacontext"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-lambda-go/otellambda"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+)
+
+func TestInstrumentHandlerHTTPResponse(t *testing.T) {
+	setEnvVars(t)
+	tp, memExporter := initMockTracerProvider()
+
+	handler := func(context.Context, events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		return events.APIGatewayProxyResponse{StatusCode: 503, Body: "unavailable"}, nil
+	}
+	wrapped := otellambda.InstrumentHandler(handler, otellambda.WithTracerProvider(tp))
+
+	ctx := lambdacontext.NewContext(context.Background(), &mockLambdaContext)
+	wrappedCallable := reflect.ValueOf(wrapped)
+	wrappedCallable.Call([]reflect.Value{reflect.ValueOf(ctx), reflect.ValueOf(map[string]interface{}{})})
+
+	spans := memExporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, codes.Error, spans[0].Status.Code)
+	assert.Contains(t, spans[0].Attributes, semconv.HTTPStatusCode(503))
+	assert.Contains(t, spans[0].Attributes, semconv.HTTPResponseContentLength(len("unavailable")))
+}
+
+func TestWrapHandlerHTTPResponse(t *testing.T) {
+	tests := []struct {
+		name     string
+		response events.APIGatewayV2HTTPResponse
+		want     codes.Code
+	}{
+		{
+			name:     "success",
+			response: events.APIGatewayV2HTTPResponse{StatusCode: 201},
+			want:     codes.Unset,
+		},
+		{
+			name:     "server error",
+			response: events.APIGatewayV2HTTPResponse{StatusCode: 500, Body: "error"},
+			want:     codes.Error,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			setEnvVars(t)
+			tp, memExporter := initMockTracerProvider()
+
+			response, err := json.Marshal(tc.response)
+			require.NoError(t, err)
+			wrapped := otellambda.WrapHandler(responseHandler{response: response},
+				otellambda.WithTracerProvider(tp),
+				otellambda.WithEventSourceDetection())
+
+			ctx := lambdacontext.NewContext(context.Background(), &mockLambdaContext)
+			_, err = wrapped.Invoke(ctx, []byte(`{"version": "2.0", "rawPath": "/", "requestContext": {"http": {"method": "GET"}}}`))
+			require.NoError(t, err)
+
+			spans := memExporter.GetSpans()
+			require.Len(t, spans, 1)
+			assert.Equal(t, tc.want, spans[0].Status.Code)
+			assert.Contains(t, spans[0].Attributes, semconv.HTTPStatusCode(tc.response.StatusCode))
+		})
+	}
+}
+
+func TestWrapHandlerHTTPResponseWithoutEventSourceDetection(t *testing.T) {
+	setEnvVars(t)
+	tp, memExporter := initMockTracerProvider()
+
+	response, err := json.Marshal(events.APIGatewayV2HTTPResponse{StatusCode: 500})
+	require.NoError(t, err)
+	wrapped := otellambda.WrapHandler(responseHandler{response: response}, otellambda.WithTracerProvider(tp))
+
+	ctx := lambdacontext.NewContext(context.Background(), &mockLambdaContext)
+	_, err = wrapped.Invoke(ctx, []byte(`{"version": "2.0", "rawPath": "/", "requestContext": {"http": {"method": "GET"}}}`))
+	require.NoError(t, err)
+
+	// The JSON encoded response is only inspected for HTTP events detected
+	// with WithEventSourceDetection.
+	spans := memExporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, codes.Unset, spans[0].Status.Code)
+	for _, attr := range spans[0].Attributes {
+		assert.NotEqual(t, semconv.HTTPStatusCodeKey, attr.Key)
+	}
+}
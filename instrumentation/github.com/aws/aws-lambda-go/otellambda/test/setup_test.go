@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/lambdacontext"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	lambdadetector "go.opentelemetry.io/contrib/detectors/aws/lambda"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-lambda-go/otellambda"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+)
+
+func TestWrapHandlerSetup(t *testing.T) {
+	setEnvVars(t)
+	t.Setenv("AWS_LAMBDA_LOG_GROUP_NAME", "/aws/lambda/testFunction")
+
+	exp := tracetest.NewInMemoryExporter()
+	var setups int
+	setup := func(ctx context.Context) []otellambda.Option {
+		setups++
+		res, err := lambdadetector.NewResourceDetector(lambdadetector.WithLogAttributes()).Detect(ctx)
+		require.NoError(t, err)
+		tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exp), sdktrace.WithResource(res))
+		return []otellambda.Option{otellambda.WithTracerProvider(tp), otellambda.WithFlusher(tp)}
+	}
+	wrapped := otellambda.WrapHandler(emptyHandler{}, otellambda.WithSetup(setup))
+
+	lc := lambdacontext.LambdaContext{
+		AwsRequestID:       "123",
+		InvokedFunctionArn: "arn:aws:lambda:us-texas-1:123456789012:function:testFunction",
+	}
+	ctx := lambdacontext.NewContext(context.Background(), &lc)
+	for n := 0; n < 2; n++ {
+		_, err := wrapped.Invoke(ctx, nil)
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, 1, setups)
+	spans := exp.GetSpans()
+	require.Len(t, spans, 2)
+	for _, span := range spans {
+		assert.Contains(t, span.Resource.Attributes(), semconv.CloudAccountID("123456789012"))
+		assert.Contains(t, span.Resource.Attributes(), semconv.AWSLogGroupNames("/aws/lambda/testFunction"))
+		assert.NotContains(t, span.Attributes, semconv.CloudAccountID("123456789012"))
+		assert.Contains(t, span.Attributes, semconv.AWSLambdaInvokedARN(lc.InvokedFunctionArn))
+	}
+}
@@ -24,7 +24,7 @@ import (
 // wrappedHandlerFunction is a struct which only holds an instrumentor and is
 // able to instrument invocations of the user's lambda handler function.
 type wrappedHandlerFunction struct {
-	instrumentor instrumentor
+	instrumentor *instrumentor
 }
 
 func errorHandler(e error) func(context.Context, interface{}) (interface{}, error) {
@@ -79,7 +79,12 @@ func (whf *wrappedHandlerFunction) wrapperInternals(ctx context.Context, handler
 	argsWrapped := []reflect.Value{reflect.ValueOf(ctx), reflect.ValueOf(eventJSON), event, reflect.ValueOf(takesContext)}
 	response := wrappedLambdaHandler.Call(argsWrapped)[0].Interface().([]reflect.Value)
 
-	// convert return values into (interface{}, error)
+	return handlerResult(response)
+}
+
+// handlerResult converts the return values of a handler into
+// (interface{}, error).
+func handlerResult(response []reflect.Value) (interface{}, error) {
 	var err error
 	if len(response) > 0 {
 		if errVal, ok := response[len(response)-1].Interface().(error); ok {
@@ -151,8 +156,12 @@ func InstrumentHandler(handlerFunc interface{}, options ...Option) interface{} {
 // Adds OTel span surrounding customer handler call.
 func (whf *wrappedHandlerFunction) wrapper(handlerFunc interface{}) func(ctx context.Context, eventJSON []byte, event interface{}, takesContext bool) []reflect.Value {
 	return func(ctx context.Context, eventJSON []byte, event interface{}, takesContext bool) []reflect.Value {
-		ctx, span := whf.instrumentor.tracingBegin(ctx, eventJSON)
-		defer whf.instrumentor.tracingEnd(ctx, span)
+		ctx, inv := whf.instrumentor.tracingBegin(ctx, eventJSON)
+		var (
+			responseJSON []byte
+			err          error
+		)
+		defer func() { whf.instrumentor.tracingEnd(ctx, inv, responseJSON, err) }()
 
 		handler := reflect.ValueOf(handlerFunc)
 		var args []reflect.Value
@@ -165,6 +174,18 @@ func (whf *wrappedHandlerFunction) wrapper(handlerFunc interface{}) func(ctx con
 
 		response := handler.Call(args)
 
+		var val interface{}
+		val, err = handlerResult(response)
+		if err == nil {
+			// Known HTTP response types are recorded even if the source of
+			// the event is not detected.
+			if r, ok := typedHTTPResponse(val); ok {
+				setHTTPResponse(inv.span, r)
+			} else if inv.inspectsResponse() && val != nil {
+				responseJSON, _ = json.Marshal(val)
+			}
+		}
+
 		return response
 	}
 }
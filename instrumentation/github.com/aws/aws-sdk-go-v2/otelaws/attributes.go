@@ -19,6 +19,11 @@ import (
 
 	v2Middleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/kinesis"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
 	"github.com/aws/aws-sdk-go-v2/service/sqs"
 	"github.com/aws/smithy-go/middleware"
 
@@ -30,12 +35,24 @@ import (
 const (
 	RegionKey    attribute.Key = "aws.region"
 	RequestIDKey attribute.Key = "aws.request_id"
+	// AttemptKey is the number of an attempt of an AWS SDK operation,
+	// starting at 1.
+	AttemptKey attribute.Key = "aws.attempt"
+	// ErrorCodeKey is the error code returned by the AWS service.
+	ErrorCodeKey attribute.Key = "aws.error_code"
+	// ThrottledKey is whether the AWS service throttled the request.
+	ThrottledKey attribute.Key = "aws.throttled"
 	AWSSystemVal string        = "aws-api"
 )
 
 var servicemap = map[string]AttributeSetter{
-	dynamodb.ServiceID: DynamoDBAttributeSetter,
-	sqs.ServiceID:      SQSAttributeSetter,
+	dynamodb.ServiceID:       DynamoDBAttributeSetter,
+	kinesis.ServiceID:        KinesisAttributeSetter,
+	lambda.ServiceID:         LambdaAttributeSetter,
+	s3.ServiceID:             S3AttributeSetter,
+	secretsmanager.ServiceID: SecretsManagerAttributeSetter,
+	sns.ServiceID:            SNSAttributeSetter,
+	sqs.ServiceID:            SQSAttributeSetter,
 }
 
 // SystemAttr return the AWS RPC system attribute.
@@ -63,6 +80,21 @@ func RequestIDAttr(requestID string) attribute.KeyValue {
 	return RequestIDKey.String(requestID)
 }
 
+// AttemptAttr returns the AWS operation attempt number attribute.
+func AttemptAttr(attempt int) attribute.KeyValue {
+	return AttemptKey.Int(attempt)
+}
+
+// ErrorCodeAttr returns the AWS error code attribute.
+func ErrorCodeAttr(code string) attribute.KeyValue {
+	return ErrorCodeKey.String(code)
+}
+
+// ThrottledAttr returns the AWS throttled request attribute.
+func ThrottledAttr(throttled bool) attribute.KeyValue {
+	return ThrottledKey.Bool(throttled)
+}
+
 // DefaultAttributeSetter checks to see if there are service specific attributes available to set for the AWS service.
 // If there are service specific attributes available then they will be included.
 func DefaultAttributeSetter(ctx context.Context, in middleware.InitializeInput) []attribute.KeyValue {
@@ -74,3 +106,19 @@ func DefaultAttributeSetter(ctx context.Context, in middleware.InitializeInput)
 
 	return []attribute.KeyValue{}
 }
+
+// appendString appends the attribute key with value v to attrs, if v is set.
+func appendString(attrs []attribute.KeyValue, key attribute.Key, v *string) []attribute.KeyValue {
+	if v == nil {
+		return attrs
+	}
+	return append(attrs, key.String(*v))
+}
+
+// appendInt32 appends the attribute key with value v to attrs, if v is set.
+func appendInt32(attrs []attribute.KeyValue, key attribute.Key, v *int32) []attribute.KeyValue {
+	if v == nil {
+		return attrs
+	}
+	return append(attrs, key.Int(int(*v)))
+}
@@ -16,15 +16,20 @@ package otelaws // import "go.opentelemetry.io/contrib/instrumentation/github.co
 
 import (
 	"context"
+	"errors"
 	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	v2Middleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/aws/smithy-go"
 	"github.com/aws/smithy-go/middleware"
 	smithyhttp "github.com/aws/smithy-go/transport/http"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/propagation"
 	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
 	"go.opentelemetry.io/otel/trace"
@@ -37,13 +42,46 @@ const (
 
 type spanTimestampKey struct{}
 
+type attemptCountKey struct{}
+
 // AttributeSetter returns an array of KeyValue pairs, it can be used to set custom attributes.
 type AttributeSetter func(context.Context, middleware.InitializeInput) []attribute.KeyValue
 
 type otelMiddlewares struct {
 	tracer          trace.Tracer
+	meter           metric.Meter
 	propagator      propagation.TextMapPropagator
 	attributeSetter []AttributeSetter
+	attemptSpans    bool
+	kinesisEnvelope KinesisRecordEnvelope
+
+	duration metric.Float64Histogram
+	attempts metric.Int64Histogram
+	errors   metric.Int64Counter
+}
+
+func (m *otelMiddlewares) createMeasures() {
+	var err error
+	m.duration, err = m.meter.Float64Histogram("rpc.client.duration",
+		metric.WithDescription("Measures the duration of AWS SDK operations, including retries."),
+		metric.WithUnit("ms"))
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	m.attempts, err = m.meter.Int64Histogram("rpc.client.attempts",
+		metric.WithDescription("Measures the number of attempts made for AWS SDK operations."),
+		metric.WithUnit("{attempt}"))
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	m.errors, err = m.meter.Int64Counter("rpc.client.errors",
+		metric.WithDescription("Measures the number of failed AWS SDK operations."),
+		metric.WithUnit("{error}"))
+	if err != nil {
+		otel.Handle(err)
+	}
 }
 
 func (m otelMiddlewares) initializeMiddlewareBefore(stack *middleware.Stack) error {
@@ -76,8 +114,9 @@ func (m otelMiddlewares) initializeMiddlewareAfter(stack *middleware.Stack) erro
 			attributes = append(attributes, setter(ctx, in)...)
 		}
 
+		start := ctx.Value(spanTimestampKey{}).(time.Time)
 		ctx, span := m.tracer.Start(ctx, spanName(serviceID, operation),
-			trace.WithTimestamp(ctx.Value(spanTimestampKey{}).(time.Time)),
+			trace.WithTimestamp(start),
 			trace.WithSpanKind(trace.SpanKindClient),
 			trace.WithAttributes(attributes...),
 		)
@@ -88,6 +127,17 @@ func (m otelMiddlewares) initializeMiddlewareAfter(stack *middleware.Stack) erro
 			span.RecordError(err)
 			span.SetStatus(codes.Error, err.Error())
 		}
+		if m.attemptSpans {
+			// The response attributes were set on the attempt spans.
+			span.SetAttributes(responseAttributes(metadata, err)...)
+		}
+
+		m.recordMetrics(ctx, start, metadata, err,
+			SystemAttr(),
+			ServiceAttr(serviceID),
+			RegionAttr(region),
+			OperationAttr(operation),
+		)
 
 		return out, metadata, err
 	}),
@@ -131,11 +181,113 @@ func (m otelMiddlewares) finalizeMiddleware(stack *middleware.Stack) error {
 		default:
 		}
 
+		if m.attemptSpans {
+			ctx = context.WithValue(ctx, attemptCountKey{}, new(int))
+		}
+
 		return next.HandleFinalize(ctx, in)
 	}),
 		middleware.Before)
 }
 
+// attemptMiddleware creates a span for every attempt made by the retryer. It
+// is inserted right after the retry middleware, so it runs once per attempt
+// and before the request is signed.
+func (m otelMiddlewares) attemptMiddleware(stack *middleware.Stack) error {
+	mw := middleware.FinalizeMiddlewareFunc("OTelAttemptMiddleware", func(
+		ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler) (
+		out middleware.FinalizeOutput, metadata middleware.Metadata, err error,
+	) {
+		attempt := 1
+		if count, ok := ctx.Value(attemptCountKey{}).(*int); ok {
+			*count++
+			attempt = *count
+		}
+
+		serviceID := v2Middleware.GetServiceID(ctx)
+		operation := v2Middleware.GetOperationName(ctx)
+		ctx, span := m.tracer.Start(ctx, spanName(serviceID, operation),
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(AttemptAttr(attempt)),
+		)
+		defer span.End()
+
+		// Propagate the attempt span so the server side is parented by it.
+		if req, ok := in.Request.(*smithyhttp.Request); ok {
+			m.propagator.Inject(ctx, propagation.HeaderCarrier(req.Header))
+		}
+
+		out, metadata, err = next.HandleFinalize(ctx, in)
+		span.SetAttributes(responseAttributes(metadata, err)...)
+		if err != nil {
+			var apiErr smithy.APIError
+			if errors.As(err, &apiErr) {
+				span.SetAttributes(ErrorCodeAttr(apiErr.ErrorCode()))
+			}
+			if retry.IsErrorThrottles(retry.DefaultThrottles).IsErrorThrottle(err) == aws.TrueTernary {
+				span.SetAttributes(ThrottledAttr(true))
+			}
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+
+		return out, metadata, err
+	})
+
+	if _, ok := stack.Finalize.Get("Retry"); ok {
+		return stack.Finalize.Insert(mw, "Retry", middleware.After)
+	}
+	return stack.Finalize.Add(mw, middleware.After)
+}
+
+// responseAttributes returns the HTTP status code and request ID attributes
+// of the last response received.
+func responseAttributes(metadata middleware.Metadata, err error) []attribute.KeyValue {
+	var attrs []attribute.KeyValue
+	if statusCode := httpStatusCode(metadata, err); statusCode > 0 {
+		attrs = append(attrs, semconv.HTTPStatusCode(statusCode))
+	}
+	if requestID, ok := v2Middleware.GetRequestIDMetadata(metadata); ok {
+		attrs = append(attrs, RequestIDAttr(requestID))
+	}
+	return attrs
+}
+
+// recordMetrics records the duration, number of attempts and error of an
+// operation started at start.
+func (m otelMiddlewares) recordMetrics(ctx context.Context, start time.Time, metadata middleware.Metadata, err error, attrs ...attribute.KeyValue) {
+	if statusCode := httpStatusCode(metadata, err); statusCode > 0 {
+		attrs = append(attrs, semconv.HTTPStatusCode(statusCode))
+	}
+	o := metric.WithAttributes(attrs...)
+
+	// Use floating point division here for higher precision (instead of Millisecond method).
+	m.duration.Record(ctx, float64(time.Since(start))/float64(time.Millisecond), o)
+
+	attempts := int64(1)
+	if results, ok := retry.GetAttemptResults(metadata); ok && len(results.Results) > 0 {
+		attempts = int64(len(results.Results))
+	}
+	m.attempts.Record(ctx, attempts, o)
+
+	if err != nil {
+		m.errors.Add(ctx, 1, o)
+	}
+}
+
+// httpStatusCode returns the HTTP status code of the last response received
+// for an operation, or 0 if it is unknown.
+func httpStatusCode(metadata middleware.Metadata, err error) int {
+	if resp, ok := v2Middleware.GetRawResponse(metadata).(*smithyhttp.Response); ok && resp != nil {
+		return resp.StatusCode
+	}
+	var respErr interface{ HTTPStatusCode() int }
+	if errors.As(err, &respErr) {
+		return respErr.HTTPStatusCode()
+	}
+	return 0
+}
+
 func spanName(serviceID, operation string) string {
 	spanName := serviceID
 	if operation != "" {
@@ -148,23 +300,34 @@ func spanName(serviceID, operation string) string {
 // OTel middlewares can be appended to either all aws clients or a specific operation.
 // Please see more details in https://aws.github.io/aws-sdk-go-v2/docs/middleware/
 func AppendMiddlewares(apiOptions *[]func(*middleware.Stack) error, opts ...Option) {
-	cfg := config{
-		TracerProvider:    otel.GetTracerProvider(),
-		TextMapPropagator: otel.GetTextMapPropagator(),
-	}
-	for _, opt := range opts {
-		opt.apply(&cfg)
-	}
+	cfg := newConfig(opts...)
 
 	if cfg.AttributeSetter == nil {
 		cfg.AttributeSetter = []AttributeSetter{DefaultAttributeSetter}
 	}
 
 	m := otelMiddlewares{
-		tracer: cfg.TracerProvider.Tracer(ScopeName,
-			trace.WithInstrumentationVersion(Version())),
+		tracer: cfg.tracer(),
+		meter: cfg.MeterProvider.Meter(ScopeName,
+			metric.WithInstrumentationVersion(Version()),
+			metric.WithSchemaURL(semconv.SchemaURL)),
 		propagator:      cfg.TextMapPropagator,
 		attributeSetter: cfg.AttributeSetter,
+		attemptSpans:    cfg.AttemptSpans,
+		kinesisEnvelope: cfg.kinesisEnvelope(),
 	}
+	m.createMeasures()
 	*apiOptions = append(*apiOptions, m.initializeMiddlewareBefore, m.initializeMiddlewareAfter, m.finalizeMiddleware, m.deserializeMiddleware)
+	if m.attemptSpans {
+		*apiOptions = append(*apiOptions, m.attemptMiddleware)
+	}
+	if cfg.SQSMessagePropagation {
+		*apiOptions = append(*apiOptions, m.sqsPropagationMiddleware)
+	}
+	if cfg.SNSMessagePropagation {
+		*apiOptions = append(*apiOptions, m.snsPropagationMiddleware)
+	}
+	if cfg.KinesisRecordPropagation {
+		*apiOptions = append(*apiOptions, m.kinesisPropagationMiddleware)
+	}
 }
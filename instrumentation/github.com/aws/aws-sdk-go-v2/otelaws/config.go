@@ -15,14 +15,48 @@
 package otelaws // import "go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-sdk-go-v2/otelaws"
 
 import (
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
 )
 
 type config struct {
 	TracerProvider    trace.TracerProvider
+	MeterProvider     metric.MeterProvider
 	TextMapPropagator propagation.TextMapPropagator
 	AttributeSetter   []AttributeSetter
+	AttemptSpans      bool
+
+	SQSMessagePropagation    bool
+	SNSMessagePropagation    bool
+	KinesisRecordPropagation bool
+	KinesisRecordEnvelope    KinesisRecordEnvelope
+}
+
+// newConfig returns a config configured with all the passed Options.
+func newConfig(opts ...Option) config {
+	cfg := config{
+		TracerProvider:    otel.GetTracerProvider(),
+		MeterProvider:     otel.GetMeterProvider(),
+		TextMapPropagator: otel.GetTextMapPropagator(),
+	}
+	for _, opt := range opts {
+		opt.apply(&cfg)
+	}
+	return cfg
+}
+
+func (cfg config) kinesisEnvelope() KinesisRecordEnvelope {
+	if cfg.KinesisRecordEnvelope == nil {
+		return JSONKinesisRecordEnvelope{}
+	}
+	return cfg.KinesisRecordEnvelope
+}
+
+func (cfg config) tracer() trace.Tracer {
+	return cfg.TracerProvider.Tracer(ScopeName,
+		trace.WithInstrumentationVersion(Version()))
 }
 
 // Option applies an option value.
@@ -48,6 +82,16 @@ func WithTracerProvider(provider trace.TracerProvider) Option {
 	})
 }
 
+// WithMeterProvider specifies a meter provider to use for creating a meter.
+// If none is specified, the global MeterProvider is used.
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return optionFunc(func(cfg *config) {
+		if provider != nil {
+			cfg.MeterProvider = provider
+		}
+	})
+}
+
 // WithTextMapPropagator specifies a Text Map Propagator to use when propagating context.
 // If none is specified, the global TextMapPropagator is used.
 func WithTextMapPropagator(propagator propagation.TextMapPropagator) Option {
@@ -65,3 +109,56 @@ func WithAttributeSetter(attributesetters ...AttributeSetter) Option {
 		cfg.AttributeSetter = append(cfg.AttributeSetter, attributesetters...)
 	})
 }
+
+// WithAttemptSpans specifies that a child span is created for every attempt
+// made by the retryer of an AWS SDK operation. Attempt spans record the
+// attempt number, the HTTP status code, the AWS error code and request ID of
+// the attempt, which makes throttled and then successful operations visible.
+// If this option is not provided, only the operation span is created.
+func WithAttemptSpans() Option {
+	return optionFunc(func(cfg *config) {
+		cfg.AttemptSpans = true
+	})
+}
+
+// WithSQSMessagePropagation specifies that the trace context is injected into
+// the MessageAttributes of the messages sent with the SQS SendMessage and
+// SendMessageBatch operations, so it can be extracted by consumers with
+// StartSQSProcessSpan. Messages that would exceed the limit of 10 message
+// attributes are sent unchanged. The propagator fields are also added to the
+// MessageAttributeNames requested by the ReceiveMessage operation.
+// If this option is not provided, the trace context is only injected into the
+// HTTP request headers.
+func WithSQSMessagePropagation() Option {
+	return optionFunc(func(cfg *config) {
+		cfg.SQSMessagePropagation = true
+	})
+}
+
+// WithSNSMessagePropagation specifies that the trace context is injected into
+// the MessageAttributes of the messages published to topics with the SNS
+// Publish and PublishBatch operations. Messages that would exceed the limit of
+// 10 message attributes are published unchanged. Consumers can extract the
+// trace context with StartSQSProcessSpan when raw message delivery is enabled,
+// or with ExtractSNSNotification otherwise.
+// If this option is not provided, the trace context is only injected into the
+// HTTP request headers.
+func WithSNSMessagePropagation() Option {
+	return optionFunc(func(cfg *config) {
+		cfg.SNSMessagePropagation = true
+	})
+}
+
+// WithKinesisRecordPropagation specifies that the data of the records put to
+// Kinesis with the PutRecord and PutRecords operations is wrapped together
+// with the trace context by envelope. If envelope is nil, the
+// JSONKinesisRecordEnvelope is used. Records that would exceed the record
+// size limit are put unchanged. Consumers can extract the trace context and
+// the original data with ExtractKinesisRecord, passed the same option.
+// If this option is not provided, the records are put unchanged.
+func WithKinesisRecordPropagation(envelope KinesisRecordEnvelope) Option {
+	return optionFunc(func(cfg *config) {
+		cfg.KinesisRecordPropagation = true
+		cfg.KinesisRecordEnvelope = envelope
+	})
+}
@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelaws // import "go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-sdk-go-v2/otelaws"
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/kinesis"
+	"github.com/aws/smithy-go/middleware"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	// KinesisStreamNameKey is the name of the Kinesis stream an operation
+	// applies to.
+	KinesisStreamNameKey attribute.Key = "aws.kinesis.stream_name"
+	// KinesisStreamARNKey is the ARN of the Kinesis stream an operation
+	// applies to.
+	KinesisStreamARNKey attribute.Key = "aws.kinesis.stream_arn"
+	// KinesisShardIDKey is the ID of the Kinesis shard an operation applies
+	// to.
+	KinesisShardIDKey attribute.Key = "aws.kinesis.shard_id"
+)
+
+// KinesisAttributeSetter sets the stream name, stream ARN and shard ID
+// attributes of Kinesis operations.
+func KinesisAttributeSetter(ctx context.Context, in middleware.InitializeInput) []attribute.KeyValue {
+	var kinesisAttributes []attribute.KeyValue
+
+	switch v := in.Parameters.(type) {
+	case *kinesis.PutRecordInput:
+		kinesisAttributes = appendString(kinesisAttributes, KinesisStreamNameKey, v.StreamName)
+		kinesisAttributes = appendString(kinesisAttributes, KinesisStreamARNKey, v.StreamARN)
+	case *kinesis.PutRecordsInput:
+		kinesisAttributes = appendString(kinesisAttributes, KinesisStreamNameKey, v.StreamName)
+		kinesisAttributes = appendString(kinesisAttributes, KinesisStreamARNKey, v.StreamARN)
+	case *kinesis.GetRecordsInput:
+		kinesisAttributes = appendString(kinesisAttributes, KinesisStreamARNKey, v.StreamARN)
+	case *kinesis.GetShardIteratorInput:
+		kinesisAttributes = appendString(kinesisAttributes, KinesisStreamNameKey, v.StreamName)
+		kinesisAttributes = appendString(kinesisAttributes, KinesisStreamARNKey, v.StreamARN)
+		kinesisAttributes = appendString(kinesisAttributes, KinesisShardIDKey, v.ShardId)
+	case *kinesis.CreateStreamInput:
+		kinesisAttributes = appendString(kinesisAttributes, KinesisStreamNameKey, v.StreamName)
+	case *kinesis.DeleteStreamInput:
+		kinesisAttributes = appendString(kinesisAttributes, KinesisStreamNameKey, v.StreamName)
+		kinesisAttributes = appendString(kinesisAttributes, KinesisStreamARNKey, v.StreamARN)
+	case *kinesis.DescribeStreamInput:
+		kinesisAttributes = appendString(kinesisAttributes, KinesisStreamNameKey, v.StreamName)
+		kinesisAttributes = appendString(kinesisAttributes, KinesisStreamARNKey, v.StreamARN)
+	case *kinesis.DescribeStreamSummaryInput:
+		kinesisAttributes = appendString(kinesisAttributes, KinesisStreamNameKey, v.StreamName)
+		kinesisAttributes = appendString(kinesisAttributes, KinesisStreamARNKey, v.StreamARN)
+	case *kinesis.ListShardsInput:
+		kinesisAttributes = appendString(kinesisAttributes, KinesisStreamNameKey, v.StreamName)
+		kinesisAttributes = appendString(kinesisAttributes, KinesisStreamARNKey, v.StreamARN)
+	case *kinesis.SubscribeToShardInput:
+		kinesisAttributes = appendString(kinesisAttributes, KinesisShardIDKey, v.ShardId)
+	}
+
+	return kinesisAttributes
+}
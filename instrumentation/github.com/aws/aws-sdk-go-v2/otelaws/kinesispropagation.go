@@ -0,0 +1,142 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelaws // import "go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-sdk-go-v2/otelaws"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/aws/aws-sdk-go-v2/service/kinesis"
+	"github.com/aws/aws-sdk-go-v2/service/kinesis/types"
+	"github.com/aws/smithy-go/middleware"
+
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// kinesisMaxRecordSize is the maximum size of the data and partition key of
+// a Kinesis record.
+const kinesisMaxRecordSize = 1 << 20
+
+// KinesisRecordEnvelope wraps the data of Kinesis records together with a
+// trace context, as Kinesis records have no attributes that could carry it.
+type KinesisRecordEnvelope interface {
+	// Wrap returns data wrapped together with the values of carrier.
+	Wrap(data []byte, carrier propagation.MapCarrier) ([]byte, error)
+	// Unwrap returns the data and the carrier wrapped by Wrap. An error is
+	// returned if data is not wrapped.
+	Unwrap(data []byte) ([]byte, propagation.MapCarrier, error)
+}
+
+// JSONKinesisRecordEnvelope is the default KinesisRecordEnvelope. It wraps
+// the data of records in a JSON document of the form
+//
+//	{"traceContext":{"traceparent":"00-..."},"data":"<base64 encoded data>"}
+type JSONKinesisRecordEnvelope struct{}
+
+var _ KinesisRecordEnvelope = JSONKinesisRecordEnvelope{}
+
+type jsonKinesisRecord struct {
+	TraceContext propagation.MapCarrier `json:"traceContext"`
+	Data         []byte                 `json:"data"`
+}
+
+var errNotWrapped = errors.New("kinesis record data is not wrapped")
+
+// Wrap returns data wrapped in a JSON document together with carrier.
+func (JSONKinesisRecordEnvelope) Wrap(data []byte, carrier propagation.MapCarrier) ([]byte, error) {
+	return json.Marshal(jsonKinesisRecord{TraceContext: carrier, Data: data})
+}
+
+// Unwrap returns the data and carrier of a JSON document created by Wrap.
+func (JSONKinesisRecordEnvelope) Unwrap(data []byte) ([]byte, propagation.MapCarrier, error) {
+	var r jsonKinesisRecord
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, nil, errNotWrapped
+	}
+	if r.TraceContext == nil || r.Data == nil {
+		return nil, nil, errNotWrapped
+	}
+	return r.Data, r.TraceContext, nil
+}
+
+// kinesisPropagationMiddleware wraps the data of the records put to Kinesis
+// with the trace context. It runs after the span of the operation is started.
+func (m otelMiddlewares) kinesisPropagationMiddleware(stack *middleware.Stack) error {
+	return stack.Initialize.Add(middleware.InitializeMiddlewareFunc("OTelKinesisPropagationMiddleware", func(
+		ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (
+		out middleware.InitializeOutput, metadata middleware.Metadata, err error,
+	) {
+		// The parameters are copied so the input of the caller is never modified.
+		switch v := in.Parameters.(type) {
+		case *kinesis.PutRecordInput:
+			params := *v
+			params.Data = m.wrapKinesisRecord(ctx, v.Data, v.PartitionKey)
+			in.Parameters = &params
+		case *kinesis.PutRecordsInput:
+			params := *v
+			params.Records = make([]types.PutRecordsRequestEntry, len(v.Records))
+			for i, record := range v.Records {
+				record.Data = m.wrapKinesisRecord(ctx, record.Data, record.PartitionKey)
+				params.Records[i] = record
+			}
+			in.Parameters = &params
+		}
+
+		return next.HandleInitialize(ctx, in)
+	}),
+		middleware.After)
+}
+
+// wrapKinesisRecord returns data wrapped with the trace context of ctx. The
+// data is returned unchanged if it cannot be wrapped or if the wrapped record
+// would exceed the record size limit.
+func (m otelMiddlewares) wrapKinesisRecord(ctx context.Context, data []byte, partitionKey *string) []byte {
+	carrier := propagation.MapCarrier{}
+	m.propagator.Inject(ctx, carrier)
+	if len(carrier) == 0 {
+		return data
+	}
+
+	wrapped, err := m.kinesisEnvelope.Wrap(data, carrier)
+	if err != nil {
+		return data
+	}
+	size := len(wrapped)
+	if partitionKey != nil {
+		size += len(*partitionKey)
+	}
+	if size > kinesisMaxRecordSize {
+		return data
+	}
+	return wrapped
+}
+
+// ExtractKinesisRecord returns a copy of ctx with the trace context extracted
+// from the data of a Kinesis record wrapped by WithKinesisRecordPropagation,
+// together with the original data of the record. If data is not wrapped, ctx
+// and data are returned unchanged, so records put by producers that are not
+// instrumented can be processed as well.
+//
+// The TextMapPropagator and WithKinesisRecordPropagation options are used,
+// all other options are ignored.
+func ExtractKinesisRecord(ctx context.Context, data []byte, opts ...Option) (context.Context, []byte) {
+	cfg := newConfig(opts...)
+	unwrapped, carrier, err := cfg.kinesisEnvelope().Unwrap(data)
+	if err != nil {
+		return ctx, data
+	}
+	return cfg.TextMapPropagator.Extract(ctx, carrier), unwrapped
+}
@@ -0,0 +1,122 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelaws
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kinesis"
+	"github.com/aws/aws-sdk-go-v2/service/kinesis/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func handleKinesisPropagation(t *testing.T, params interface{}) interface{} {
+	t.Helper()
+	return handlePropagation(t, otelMiddlewares.kinesisPropagationMiddleware, params)
+}
+
+func TestKinesisPropagationPutRecord(t *testing.T) {
+	input := &kinesis.PutRecordInput{
+		StreamName:   aws.String("test-stream"),
+		PartitionKey: aws.String("key"),
+		Data:         []byte("data"),
+	}
+
+	got, ok := handleKinesisPropagation(t, input).(*kinesis.PutRecordInput)
+	require.True(t, ok)
+	assert.Equal(t, []byte("data"), input.Data)
+
+	opt := WithTextMapPropagator(propagation.TraceContext{})
+	ctx, data := ExtractKinesisRecord(context.Background(), got.Data, opt)
+	assert.Equal(t, []byte("data"), data)
+	sc := trace.SpanContextFromContext(ctx)
+	assert.True(t, sc.IsRemote())
+	assert.Equal(t, trace.SpanContextFromContext(testSpanContext()).SpanID(), sc.SpanID())
+}
+
+func TestKinesisPropagationPutRecordSizeLimit(t *testing.T) {
+	data := bytes.Repeat([]byte("a"), kinesisMaxRecordSize-1)
+
+	got, ok := handleKinesisPropagation(t, &kinesis.PutRecordInput{
+		PartitionKey: aws.String("k"),
+		Data:         data,
+	}).(*kinesis.PutRecordInput)
+	require.True(t, ok)
+	assert.Equal(t, data, got.Data)
+}
+
+func TestKinesisPropagationPutRecords(t *testing.T) {
+	input := &kinesis.PutRecordsInput{
+		StreamName: aws.String("test-stream"),
+		Records: []types.PutRecordsRequestEntry{
+			{PartitionKey: aws.String("1"), Data: []byte("first")},
+			{PartitionKey: aws.String("2"), Data: []byte("second")},
+		},
+	}
+
+	got, ok := handleKinesisPropagation(t, input).(*kinesis.PutRecordsInput)
+	require.True(t, ok)
+	require.Len(t, got.Records, 2)
+
+	opt := WithTextMapPropagator(propagation.TraceContext{})
+	for i, want := range []string{"first", "second"} {
+		ctx, data := ExtractKinesisRecord(context.Background(), got.Records[i].Data, opt)
+		assert.Equal(t, []byte(want), data)
+		assert.True(t, trace.SpanContextFromContext(ctx).IsValid())
+		assert.Equal(t, []byte(want), input.Records[i].Data)
+	}
+}
+
+func TestExtractKinesisRecordNotWrapped(t *testing.T) {
+	for _, data := range [][]byte{
+		[]byte("plain data"),
+		[]byte(`{"some":"json"}`),
+	} {
+		ctx, got := ExtractKinesisRecord(context.Background(), data, WithTextMapPropagator(propagation.TraceContext{}))
+		assert.Equal(t, data, got)
+		assert.False(t, trace.SpanContextFromContext(ctx).IsValid())
+	}
+}
+
+type testEnvelope struct{}
+
+func (testEnvelope) Wrap(data []byte, carrier propagation.MapCarrier) ([]byte, error) {
+	return append([]byte(carrier.Get("traceparent")+"|"), data...), nil
+}
+
+func (testEnvelope) Unwrap(data []byte) ([]byte, propagation.MapCarrier, error) {
+	idx := bytes.IndexByte(data, '|')
+	if idx < 0 {
+		return nil, nil, errNotWrapped
+	}
+	return data[idx+1:], propagation.MapCarrier{"traceparent": string(data[:idx])}, nil
+}
+
+func TestExtractKinesisRecordCustomEnvelope(t *testing.T) {
+	data := []byte(testTraceparent + "|data")
+	ctx, got := ExtractKinesisRecord(context.Background(), data,
+		WithTextMapPropagator(propagation.TraceContext{}),
+		WithKinesisRecordPropagation(testEnvelope{}),
+	)
+	assert.Equal(t, []byte("data"), got)
+	assert.True(t, trace.SpanContextFromContext(ctx).IsValid())
+}
@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelaws // import "go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-sdk-go-v2/otelaws"
+
+import (
+	"context"
+	"strings"
+
+	v2Middleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/smithy-go/middleware"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+)
+
+// LambdaQualifierKey is the version or alias of the Lambda function an
+// operation applies to.
+const LambdaQualifierKey attribute.Key = "aws.lambda.qualifier"
+
+// LambdaAttributeSetter sets the invoked function name and qualifier
+// attributes of Lambda operations.
+func LambdaAttributeSetter(ctx context.Context, in middleware.InitializeInput) []attribute.KeyValue {
+	var lambdaAttributes []attribute.KeyValue
+
+	var functionName, qualifier *string
+	switch v := in.Parameters.(type) {
+	case *lambda.InvokeInput:
+		functionName, qualifier = v.FunctionName, v.Qualifier
+	case *lambda.InvokeWithResponseStreamInput:
+		functionName, qualifier = v.FunctionName, v.Qualifier
+	case *lambda.GetFunctionInput:
+		functionName, qualifier = v.FunctionName, v.Qualifier
+	case *lambda.GetFunctionConfigurationInput:
+		functionName, qualifier = v.FunctionName, v.Qualifier
+	default:
+		return lambdaAttributes
+	}
+
+	lambdaAttributes = append(lambdaAttributes, semconv.FaaSInvokedProviderAWS)
+	if region := v2Middleware.GetRegion(ctx); region != "" {
+		lambdaAttributes = append(lambdaAttributes, semconv.FaaSInvokedRegion(region))
+	}
+	if functionName != nil {
+		// The function name can be a name, a partial ARN or a full ARN.
+		name := *functionName
+		if strings.HasPrefix(name, "arn:") {
+			lambdaAttributes = append(lambdaAttributes, semconv.AWSLambdaInvokedARN(name))
+		}
+		switch parts := strings.Split(name, ":"); {
+		case len(parts) >= 7:
+			// Full ARN: arn:aws:lambda:us-west-2:123456789012:function:my-function.
+			name = parts[6]
+		case len(parts) >= 3:
+			// Partial ARN: 123456789012:function:my-function.
+			name = parts[2]
+		case len(parts) == 2:
+			// Name with qualifier: my-function:v1.
+			name = parts[0]
+		}
+		lambdaAttributes = append(lambdaAttributes, semconv.FaaSInvokedName(name))
+	}
+	lambdaAttributes = appendString(lambdaAttributes, LambdaQualifierKey, qualifier)
+
+	return lambdaAttributes
+}
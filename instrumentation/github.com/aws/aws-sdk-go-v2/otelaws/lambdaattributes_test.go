@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelaws
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/smithy-go/middleware"
+	"github.com/stretchr/testify/assert"
+
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+)
+
+func TestLambdaInvokeInput(t *testing.T) {
+	tests := []struct {
+		functionName string
+		wantARN      bool
+	}{
+		{functionName: "test-function"},
+		{functionName: "test-function:v1"},
+		{functionName: "123456789012:function:test-function"},
+		{functionName: "arn:aws:lambda:us-west-2:123456789012:function:test-function", wantARN: true},
+		{functionName: "arn:aws:lambda:us-west-2:123456789012:function:test-function:alias", wantARN: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.functionName, func(t *testing.T) {
+			input := middleware.InitializeInput{
+				Parameters: &lambda.InvokeInput{
+					FunctionName: aws.String(tc.functionName),
+					Qualifier:    aws.String("alias"),
+				},
+			}
+			attributes := LambdaAttributeSetter(context.TODO(), input)
+
+			assert.Contains(t, attributes, semconv.FaaSInvokedProviderAWS)
+			assert.Contains(t, attributes, semconv.FaaSInvokedName("test-function"))
+			assert.Contains(t, attributes, LambdaQualifierKey.String("alias"))
+			if tc.wantARN {
+				assert.Contains(t, attributes, semconv.AWSLambdaInvokedARN(tc.functionName))
+			} else {
+				assert.NotContains(t, attributes, semconv.AWSLambdaInvokedARN(tc.functionName))
+			}
+		})
+	}
+}
+
+func TestLambdaOtherInput(t *testing.T) {
+	input := middleware.InitializeInput{
+		Parameters: &lambda.ListFunctionsInput{},
+	}
+
+	attributes := LambdaAttributeSetter(context.TODO(), input)
+
+	assert.Empty(t, attributes)
+}
@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelaws // import "go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-sdk-go-v2/otelaws"
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/smithy-go/middleware"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+)
+
+// S3AttributeSetter sets the bucket, key, copy source, upload ID and part
+// number attributes of S3 operations.
+func S3AttributeSetter(ctx context.Context, in middleware.InitializeInput) []attribute.KeyValue {
+	var s3Attributes []attribute.KeyValue
+
+	switch v := in.Parameters.(type) {
+	case *s3.AbortMultipartUploadInput:
+		s3Attributes = appendString(s3Attributes, semconv.AWSS3BucketKey, v.Bucket)
+		s3Attributes = appendString(s3Attributes, semconv.AWSS3KeyKey, v.Key)
+		s3Attributes = appendString(s3Attributes, semconv.AWSS3UploadIDKey, v.UploadId)
+	case *s3.CompleteMultipartUploadInput:
+		s3Attributes = appendString(s3Attributes, semconv.AWSS3BucketKey, v.Bucket)
+		s3Attributes = appendString(s3Attributes, semconv.AWSS3KeyKey, v.Key)
+		s3Attributes = appendString(s3Attributes, semconv.AWSS3UploadIDKey, v.UploadId)
+	case *s3.CopyObjectInput:
+		s3Attributes = appendString(s3Attributes, semconv.AWSS3BucketKey, v.Bucket)
+		s3Attributes = appendString(s3Attributes, semconv.AWSS3KeyKey, v.Key)
+		s3Attributes = appendString(s3Attributes, semconv.AWSS3CopySourceKey, v.CopySource)
+	case *s3.CreateBucketInput:
+		s3Attributes = appendString(s3Attributes, semconv.AWSS3BucketKey, v.Bucket)
+	case *s3.CreateMultipartUploadInput:
+		s3Attributes = appendString(s3Attributes, semconv.AWSS3BucketKey, v.Bucket)
+		s3Attributes = appendString(s3Attributes, semconv.AWSS3KeyKey, v.Key)
+	case *s3.DeleteBucketInput:
+		s3Attributes = appendString(s3Attributes, semconv.AWSS3BucketKey, v.Bucket)
+	case *s3.DeleteObjectInput:
+		s3Attributes = appendString(s3Attributes, semconv.AWSS3BucketKey, v.Bucket)
+		s3Attributes = appendString(s3Attributes, semconv.AWSS3KeyKey, v.Key)
+	case *s3.DeleteObjectsInput:
+		s3Attributes = appendString(s3Attributes, semconv.AWSS3BucketKey, v.Bucket)
+	case *s3.GetObjectInput:
+		s3Attributes = appendString(s3Attributes, semconv.AWSS3BucketKey, v.Bucket)
+		s3Attributes = appendString(s3Attributes, semconv.AWSS3KeyKey, v.Key)
+		s3Attributes = appendInt32(s3Attributes, semconv.AWSS3PartNumberKey, v.PartNumber)
+	case *s3.HeadBucketInput:
+		s3Attributes = appendString(s3Attributes, semconv.AWSS3BucketKey, v.Bucket)
+	case *s3.HeadObjectInput:
+		s3Attributes = appendString(s3Attributes, semconv.AWSS3BucketKey, v.Bucket)
+		s3Attributes = appendString(s3Attributes, semconv.AWSS3KeyKey, v.Key)
+		s3Attributes = appendInt32(s3Attributes, semconv.AWSS3PartNumberKey, v.PartNumber)
+	case *s3.ListMultipartUploadsInput:
+		s3Attributes = appendString(s3Attributes, semconv.AWSS3BucketKey, v.Bucket)
+	case *s3.ListObjectsInput:
+		s3Attributes = appendString(s3Attributes, semconv.AWSS3BucketKey, v.Bucket)
+	case *s3.ListObjectsV2Input:
+		s3Attributes = appendString(s3Attributes, semconv.AWSS3BucketKey, v.Bucket)
+	case *s3.ListPartsInput:
+		s3Attributes = appendString(s3Attributes, semconv.AWSS3BucketKey, v.Bucket)
+		s3Attributes = appendString(s3Attributes, semconv.AWSS3KeyKey, v.Key)
+		s3Attributes = appendString(s3Attributes, semconv.AWSS3UploadIDKey, v.UploadId)
+	case *s3.PutObjectInput:
+		s3Attributes = appendString(s3Attributes, semconv.AWSS3BucketKey, v.Bucket)
+		s3Attributes = appendString(s3Attributes, semconv.AWSS3KeyKey, v.Key)
+	case *s3.UploadPartInput:
+		s3Attributes = appendString(s3Attributes, semconv.AWSS3BucketKey, v.Bucket)
+		s3Attributes = appendString(s3Attributes, semconv.AWSS3KeyKey, v.Key)
+		s3Attributes = appendString(s3Attributes, semconv.AWSS3UploadIDKey, v.UploadId)
+		s3Attributes = appendInt32(s3Attributes, semconv.AWSS3PartNumberKey, v.PartNumber)
+	case *s3.UploadPartCopyInput:
+		s3Attributes = appendString(s3Attributes, semconv.AWSS3BucketKey, v.Bucket)
+		s3Attributes = appendString(s3Attributes, semconv.AWSS3KeyKey, v.Key)
+		s3Attributes = appendString(s3Attributes, semconv.AWSS3CopySourceKey, v.CopySource)
+		s3Attributes = appendString(s3Attributes, semconv.AWSS3UploadIDKey, v.UploadId)
+		s3Attributes = appendInt32(s3Attributes, semconv.AWSS3PartNumberKey, v.PartNumber)
+	}
+
+	return s3Attributes
+}
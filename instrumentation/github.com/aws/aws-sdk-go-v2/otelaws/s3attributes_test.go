@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelaws
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/smithy-go/middleware"
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+)
+
+func TestS3GetObjectInput(t *testing.T) {
+	input := middleware.InitializeInput{
+		Parameters: &s3.GetObjectInput{
+			Bucket:     aws.String("test-bucket"),
+			Key:        aws.String("test-key"),
+			PartNumber: aws.Int32(2),
+		},
+	}
+
+	attributes := S3AttributeSetter(context.TODO(), input)
+
+	assert.Contains(t, attributes, semconv.AWSS3Bucket("test-bucket"))
+	assert.Contains(t, attributes, semconv.AWSS3Key("test-key"))
+	assert.Contains(t, attributes, semconv.AWSS3PartNumber(2))
+}
+
+func TestS3PutObjectInput(t *testing.T) {
+	input := middleware.InitializeInput{
+		Parameters: &s3.PutObjectInput{
+			Bucket: aws.String("test-bucket"),
+			Key:    aws.String("test-key"),
+		},
+	}
+
+	attributes := S3AttributeSetter(context.TODO(), input)
+
+	assert.Equal(t, []attribute.KeyValue{
+		semconv.AWSS3Bucket("test-bucket"),
+		semconv.AWSS3Key("test-key"),
+	}, attributes)
+}
+
+func TestS3CopyObjectInput(t *testing.T) {
+	input := middleware.InitializeInput{
+		Parameters: &s3.CopyObjectInput{
+			Bucket:     aws.String("test-bucket"),
+			Key:        aws.String("test-key"),
+			CopySource: aws.String("source-bucket/source-key"),
+		},
+	}
+
+	attributes := S3AttributeSetter(context.TODO(), input)
+
+	assert.Contains(t, attributes, semconv.AWSS3CopySource("source-bucket/source-key"))
+}
+
+func TestS3UploadPartInput(t *testing.T) {
+	input := middleware.InitializeInput{
+		Parameters: &s3.UploadPartInput{
+			Bucket:     aws.String("test-bucket"),
+			Key:        aws.String("test-key"),
+			UploadId:   aws.String("test-upload-id"),
+			PartNumber: aws.Int32(3),
+		},
+	}
+
+	attributes := S3AttributeSetter(context.TODO(), input)
+
+	assert.Contains(t, attributes, semconv.AWSS3UploadID("test-upload-id"))
+	assert.Contains(t, attributes, semconv.AWSS3PartNumber(3))
+}
+
+func TestS3ListObjectsV2Input(t *testing.T) {
+	input := middleware.InitializeInput{
+		Parameters: &s3.ListObjectsV2Input{
+			Bucket: aws.String("test-bucket"),
+		},
+	}
+
+	attributes := S3AttributeSetter(context.TODO(), input)
+
+	assert.Equal(t, []attribute.KeyValue{semconv.AWSS3Bucket("test-bucket")}, attributes)
+}
@@ -0,0 +1,57 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelaws // import "go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-sdk-go-v2/otelaws"
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/aws/smithy-go/middleware"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// SecretsManagerSecretIDKey is the name or ARN of the secret an operation
+// applies to.
+const SecretsManagerSecretIDKey attribute.Key = "aws.secretsmanager.secret_id"
+
+// SecretsManagerAttributeSetter sets the secret ID attribute of Secrets
+// Manager operations.
+func SecretsManagerAttributeSetter(ctx context.Context, in middleware.InitializeInput) []attribute.KeyValue {
+	var secretsManagerAttributes []attribute.KeyValue
+
+	switch v := in.Parameters.(type) {
+	case *secretsmanager.CreateSecretInput:
+		secretsManagerAttributes = appendString(secretsManagerAttributes, SecretsManagerSecretIDKey, v.Name)
+	case *secretsmanager.DeleteSecretInput:
+		secretsManagerAttributes = appendString(secretsManagerAttributes, SecretsManagerSecretIDKey, v.SecretId)
+	case *secretsmanager.DescribeSecretInput:
+		secretsManagerAttributes = appendString(secretsManagerAttributes, SecretsManagerSecretIDKey, v.SecretId)
+	case *secretsmanager.GetSecretValueInput:
+		secretsManagerAttributes = appendString(secretsManagerAttributes, SecretsManagerSecretIDKey, v.SecretId)
+	case *secretsmanager.PutSecretValueInput:
+		secretsManagerAttributes = appendString(secretsManagerAttributes, SecretsManagerSecretIDKey, v.SecretId)
+	case *secretsmanager.RestoreSecretInput:
+		secretsManagerAttributes = appendString(secretsManagerAttributes, SecretsManagerSecretIDKey, v.SecretId)
+	case *secretsmanager.RotateSecretInput:
+		secretsManagerAttributes = appendString(secretsManagerAttributes, SecretsManagerSecretIDKey, v.SecretId)
+	case *secretsmanager.UpdateSecretInput:
+		secretsManagerAttributes = appendString(secretsManagerAttributes, SecretsManagerSecretIDKey, v.SecretId)
+	case *secretsmanager.UpdateSecretVersionStageInput:
+		secretsManagerAttributes = appendString(secretsManagerAttributes, SecretsManagerSecretIDKey, v.SecretId)
+	}
+
+	return secretsManagerAttributes
+}
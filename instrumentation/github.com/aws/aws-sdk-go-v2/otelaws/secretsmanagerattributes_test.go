@@ -0,0 +1,51 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelaws
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/aws/smithy-go/middleware"
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func TestSecretsManagerGetSecretValueInput(t *testing.T) {
+	input := middleware.InitializeInput{
+		Parameters: &secretsmanager.GetSecretValueInput{
+			SecretId: aws.String("test-secret"),
+		},
+	}
+
+	attributes := SecretsManagerAttributeSetter(context.TODO(), input)
+
+	assert.Equal(t, []attribute.KeyValue{SecretsManagerSecretIDKey.String("test-secret")}, attributes)
+}
+
+func TestSecretsManagerCreateSecretInput(t *testing.T) {
+	input := middleware.InitializeInput{
+		Parameters: &secretsmanager.CreateSecretInput{
+			Name: aws.String("test-secret"),
+		},
+	}
+
+	attributes := SecretsManagerAttributeSetter(context.TODO(), input)
+
+	assert.Equal(t, []attribute.KeyValue{SecretsManagerSecretIDKey.String("test-secret")}, attributes)
+}
@@ -0,0 +1,85 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelaws // import "go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-sdk-go-v2/otelaws"
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	"github.com/aws/smithy-go/middleware"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+)
+
+// SNSTopicARNKey is the ARN of the SNS topic an operation applies to.
+const SNSTopicARNKey attribute.Key = "aws.sns.topic_arn"
+
+// SNSAttributeSetter sets the topic ARN and the messaging destination name
+// attributes of SNS operations.
+func SNSAttributeSetter(ctx context.Context, in middleware.InitializeInput) []attribute.KeyValue {
+	snsAttributes := []attribute.KeyValue{semconv.MessagingSystem("AmazonSNS")}
+
+	var topicARN *string
+	switch v := in.Parameters.(type) {
+	case *sns.PublishInput:
+		topicARN = v.TopicArn
+		if topicARN == nil {
+			topicARN = v.TargetArn
+		}
+		if topicARN != nil {
+			snsAttributes = append(snsAttributes, semconv.MessagingOperationPublish)
+		}
+	case *sns.PublishBatchInput:
+		topicARN = v.TopicArn
+		snsAttributes = append(snsAttributes,
+			semconv.MessagingOperationPublish,
+			semconv.MessagingBatchMessageCount(len(v.PublishBatchRequestEntries)),
+		)
+	case *sns.CreateTopicInput:
+		if v.Name != nil {
+			snsAttributes = append(snsAttributes, semconv.MessagingDestinationName(*v.Name))
+		}
+	case *sns.DeleteTopicInput:
+		topicARN = v.TopicArn
+	case *sns.GetTopicAttributesInput:
+		topicARN = v.TopicArn
+	case *sns.ListSubscriptionsByTopicInput:
+		topicARN = v.TopicArn
+	case *sns.SetTopicAttributesInput:
+		topicARN = v.TopicArn
+	case *sns.SubscribeInput:
+		topicARN = v.TopicArn
+	}
+
+	if topicARN != nil {
+		snsAttributes = append(snsAttributes,
+			SNSTopicARNKey.String(*topicARN),
+			semconv.MessagingDestinationName(arnResource(*topicARN)),
+		)
+	}
+
+	return snsAttributes
+}
+
+// arnResource returns the resource part of an ARN, the part after the last
+// colon.
+func arnResource(arn string) string {
+	if idx := strings.LastIndexByte(arn, ':'); idx >= 0 {
+		return arn[idx+1:]
+	}
+	return arn
+}
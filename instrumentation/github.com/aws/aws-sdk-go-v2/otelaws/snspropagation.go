@@ -0,0 +1,143 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelaws // import "go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-sdk-go-v2/otelaws"
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	"github.com/aws/aws-sdk-go-v2/service/sns/types"
+	"github.com/aws/smithy-go/middleware"
+
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// snsMaxMessageAttributes is the maximum number of message attributes of an
+// SNS message delivered to SQS.
+const snsMaxMessageAttributes = 10
+
+// SNSMessageAttributeCarrier is a TextMapCarrier that uses the
+// MessageAttributes of an SNS message to store and retrieve values.
+type SNSMessageAttributeCarrier map[string]types.MessageAttributeValue
+
+var _ propagation.TextMapCarrier = SNSMessageAttributeCarrier{}
+
+// Get returns the string value associated with the passed key.
+func (c SNSMessageAttributeCarrier) Get(key string) string {
+	v, ok := c[key]
+	if !ok || v.StringValue == nil {
+		return ""
+	}
+	return *v.StringValue
+}
+
+// Set stores the key-value pair as a String message attribute.
+func (c SNSMessageAttributeCarrier) Set(key, value string) {
+	c[key] = types.MessageAttributeValue{
+		DataType:    aws.String("String"),
+		StringValue: aws.String(value),
+	}
+}
+
+// Keys lists the keys stored in this carrier.
+func (c SNSMessageAttributeCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// snsPropagationMiddleware injects the trace context into the messages
+// published to SNS. It runs after the span of the operation is started.
+func (m otelMiddlewares) snsPropagationMiddleware(stack *middleware.Stack) error {
+	return stack.Initialize.Add(middleware.InitializeMiddlewareFunc("OTelSNSPropagationMiddleware", func(
+		ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (
+		out middleware.InitializeOutput, metadata middleware.Metadata, err error,
+	) {
+		// The parameters are copied so the input of the caller is never modified.
+		switch v := in.Parameters.(type) {
+		case *sns.PublishInput:
+			// Messages sent directly to a phone number are not delivered to
+			// consumers that could extract the trace context.
+			if v.TopicArn != nil || v.TargetArn != nil {
+				params := *v
+				params.MessageAttributes = m.injectSNSMessageAttributes(ctx, v.MessageAttributes)
+				in.Parameters = &params
+			}
+		case *sns.PublishBatchInput:
+			params := *v
+			params.PublishBatchRequestEntries = make([]types.PublishBatchRequestEntry, len(v.PublishBatchRequestEntries))
+			for i, entry := range v.PublishBatchRequestEntries {
+				entry.MessageAttributes = m.injectSNSMessageAttributes(ctx, entry.MessageAttributes)
+				params.PublishBatchRequestEntries[i] = entry
+			}
+			in.Parameters = &params
+		}
+
+		return next.HandleInitialize(ctx, in)
+	}),
+		middleware.After)
+}
+
+// injectSNSMessageAttributes returns a copy of attrs with the trace context of
+// ctx injected. The attrs are returned unchanged if the injection would
+// exceed the message attributes limit.
+func (m otelMiddlewares) injectSNSMessageAttributes(ctx context.Context, attrs map[string]types.MessageAttributeValue) map[string]types.MessageAttributeValue {
+	carrier := make(SNSMessageAttributeCarrier, len(attrs))
+	for k, v := range attrs {
+		carrier[k] = v
+	}
+	m.propagator.Inject(ctx, carrier)
+	if len(carrier) > snsMaxMessageAttributes {
+		return attrs
+	}
+	return carrier
+}
+
+// snsNotification is the JSON document delivered by SNS to SQS queues and
+// HTTP endpoints when raw message delivery is disabled.
+type snsNotification struct {
+	MessageAttributes map[string]struct {
+		Type  string
+		Value string
+	}
+}
+
+// ExtractSNSNotification returns a copy of ctx with the trace context
+// extracted from the message attributes of an SNS notification, as delivered
+// to SQS queues and HTTP endpoints when raw message delivery is disabled. The
+// trace context is injected by WithSNSMessagePropagation. When raw message
+// delivery is enabled, the message attributes are delivered as SQS message
+// attributes instead and StartSQSProcessSpan can be used.
+//
+// The TextMapPropagator option is used, all other options are ignored. ctx is
+// returned unchanged if body is not an SNS notification.
+func ExtractSNSNotification(ctx context.Context, body []byte, opts ...Option) context.Context {
+	var n snsNotification
+	if err := json.Unmarshal(body, &n); err != nil {
+		return ctx
+	}
+
+	carrier := make(propagation.MapCarrier, len(n.MessageAttributes))
+	for k, v := range n.MessageAttributes {
+		if v.Type == "String" {
+			carrier[k] = v.Value
+		}
+	}
+	return newConfig(opts...).TextMapPropagator.Extract(ctx, carrier)
+}
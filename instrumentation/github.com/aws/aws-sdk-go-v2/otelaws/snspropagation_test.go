@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelaws
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	"github.com/aws/aws-sdk-go-v2/service/sns/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func handleSNSPropagation(t *testing.T, params interface{}) interface{} {
+	t.Helper()
+	return handlePropagation(t, otelMiddlewares.snsPropagationMiddleware, params)
+}
+
+func TestSNSPropagationPublish(t *testing.T) {
+	input := &sns.PublishInput{
+		TopicArn: aws.String("arn:aws:sns:us-east-1:123456789012:test-topic"),
+		Message:  aws.String("message"),
+	}
+
+	got, ok := handleSNSPropagation(t, input).(*sns.PublishInput)
+	require.True(t, ok)
+	assert.Equal(t, testTraceparent, SNSMessageAttributeCarrier(got.MessageAttributes).Get("traceparent"))
+	assert.Nil(t, input.MessageAttributes)
+}
+
+func TestSNSPropagationPublishPhoneNumber(t *testing.T) {
+	input := &sns.PublishInput{
+		PhoneNumber: aws.String("+15555550100"),
+		Message:     aws.String("message"),
+	}
+
+	got, ok := handleSNSPropagation(t, input).(*sns.PublishInput)
+	require.True(t, ok)
+	assert.Nil(t, got.MessageAttributes)
+}
+
+func TestSNSPropagationPublishAttributeLimit(t *testing.T) {
+	attrs := SNSMessageAttributeCarrier{}
+	for i := 0; i < snsMaxMessageAttributes; i++ {
+		attrs.Set(fmt.Sprintf("attr%d", i), "value")
+	}
+
+	got, ok := handleSNSPropagation(t, &sns.PublishInput{
+		TopicArn:          aws.String("arn:aws:sns:us-east-1:123456789012:test-topic"),
+		MessageAttributes: attrs,
+	}).(*sns.PublishInput)
+	require.True(t, ok)
+	assert.Len(t, got.MessageAttributes, snsMaxMessageAttributes)
+	assert.NotContains(t, got.MessageAttributes, "traceparent")
+}
+
+func TestSNSPropagationPublishBatch(t *testing.T) {
+	input := &sns.PublishBatchInput{
+		TopicArn: aws.String("arn:aws:sns:us-east-1:123456789012:test-topic"),
+		PublishBatchRequestEntries: []types.PublishBatchRequestEntry{
+			{Id: aws.String("1")},
+			{Id: aws.String("2")},
+		},
+	}
+
+	got, ok := handleSNSPropagation(t, input).(*sns.PublishBatchInput)
+	require.True(t, ok)
+	require.Len(t, got.PublishBatchRequestEntries, 2)
+	for _, entry := range got.PublishBatchRequestEntries {
+		assert.Equal(t, testTraceparent, SNSMessageAttributeCarrier(entry.MessageAttributes).Get("traceparent"))
+	}
+	assert.Nil(t, input.PublishBatchRequestEntries[0].MessageAttributes)
+}
+
+func TestExtractSNSNotification(t *testing.T) {
+	body := []byte(`{
+		"Type": "Notification",
+		"MessageId": "message-id",
+		"TopicArn": "arn:aws:sns:us-east-1:123456789012:test-topic",
+		"Message": "message",
+		"MessageAttributes": {
+			"traceparent": {"Type": "String", "Value": "` + testTraceparent + `"}
+		}
+	}`)
+
+	ctx := ExtractSNSNotification(context.Background(), body, WithTextMapPropagator(propagation.TraceContext{}))
+	sc := trace.SpanContextFromContext(ctx)
+	assert.True(t, sc.IsRemote())
+	assert.Equal(t, trace.SpanContextFromContext(testSpanContext()).TraceID(), sc.TraceID())
+
+	ctx = ExtractSNSNotification(context.Background(), []byte("not json"), WithTextMapPropagator(propagation.TraceContext{}))
+	assert.False(t, trace.SpanContextFromContext(ctx).IsValid())
+}
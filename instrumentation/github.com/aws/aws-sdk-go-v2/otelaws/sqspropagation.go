@@ -0,0 +1,171 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelaws // import "go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-sdk-go-v2/otelaws"
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/aws/smithy-go/middleware"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// sqsMaxMessageAttributes is the maximum number of message attributes of an
+// SQS message.
+const sqsMaxMessageAttributes = 10
+
+// SQSMessageAttributeCarrier is a TextMapCarrier that uses the
+// MessageAttributes of an SQS message to store and retrieve values.
+type SQSMessageAttributeCarrier map[string]types.MessageAttributeValue
+
+var _ propagation.TextMapCarrier = SQSMessageAttributeCarrier{}
+
+// Get returns the string value associated with the passed key.
+func (c SQSMessageAttributeCarrier) Get(key string) string {
+	v, ok := c[key]
+	if !ok || v.StringValue == nil {
+		return ""
+	}
+	return *v.StringValue
+}
+
+// Set stores the key-value pair as a String message attribute.
+func (c SQSMessageAttributeCarrier) Set(key, value string) {
+	c[key] = types.MessageAttributeValue{
+		DataType:    aws.String("String"),
+		StringValue: aws.String(value),
+	}
+}
+
+// Keys lists the keys stored in this carrier.
+func (c SQSMessageAttributeCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// sqsPropagationMiddleware injects the trace context into the messages sent
+// to SQS and requests the propagator fields when receiving messages. It runs
+// after the span of the operation is started.
+func (m otelMiddlewares) sqsPropagationMiddleware(stack *middleware.Stack) error {
+	return stack.Initialize.Add(middleware.InitializeMiddlewareFunc("OTelSQSPropagationMiddleware", func(
+		ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (
+		out middleware.InitializeOutput, metadata middleware.Metadata, err error,
+	) {
+		// The parameters are copied so the input of the caller is never modified.
+		switch v := in.Parameters.(type) {
+		case *sqs.SendMessageInput:
+			params := *v
+			params.MessageAttributes = m.injectSQSMessageAttributes(ctx, v.MessageAttributes)
+			in.Parameters = &params
+		case *sqs.SendMessageBatchInput:
+			params := *v
+			params.Entries = make([]types.SendMessageBatchRequestEntry, len(v.Entries))
+			for i, entry := range v.Entries {
+				entry.MessageAttributes = m.injectSQSMessageAttributes(ctx, entry.MessageAttributes)
+				params.Entries[i] = entry
+			}
+			in.Parameters = &params
+		case *sqs.ReceiveMessageInput:
+			params := *v
+			params.MessageAttributeNames = withSQSAttributeNames(v.MessageAttributeNames, m.propagator.Fields())
+			in.Parameters = &params
+		}
+
+		return next.HandleInitialize(ctx, in)
+	}),
+		middleware.After)
+}
+
+// injectSQSMessageAttributes returns a copy of attrs with the trace context of
+// ctx injected. The attrs are returned unchanged if the injection would
+// exceed the message attributes limit.
+func (m otelMiddlewares) injectSQSMessageAttributes(ctx context.Context, attrs map[string]types.MessageAttributeValue) map[string]types.MessageAttributeValue {
+	carrier := make(SQSMessageAttributeCarrier, len(attrs))
+	for k, v := range attrs {
+		carrier[k] = v
+	}
+	m.propagator.Inject(ctx, carrier)
+	if len(carrier) > sqsMaxMessageAttributes {
+		return attrs
+	}
+	return carrier
+}
+
+// withSQSAttributeNames returns names with fields appended, unless all
+// message attributes are already requested.
+func withSQSAttributeNames(names, fields []string) []string {
+	requested := make(map[string]bool, len(names))
+	for _, name := range names {
+		if name == "All" || name == ".*" {
+			return names
+		}
+		requested[name] = true
+	}
+
+	out := make([]string, len(names), len(names)+len(fields))
+	copy(out, names)
+	for _, f := range fields {
+		if !requested[f] {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// StartSQSProcessSpan starts a consumer span for the processing of msg,
+// received from the SQS queue at queueURL. The span is a child of the span in
+// ctx and is linked to the producer span whose trace context was injected
+// into the MessageAttributes of msg, as done with WithSQSMessagePropagation.
+//
+// The TracerProvider and TextMapPropagator options are used, all other
+// options are ignored. The caller must end the returned span.
+func StartSQSProcessSpan(ctx context.Context, queueURL string, msg types.Message, opts ...Option) (context.Context, trace.Span) {
+	cfg := newConfig(opts...)
+
+	queue := queueURL
+	if idx := strings.LastIndexByte(queue, '/'); idx >= 0 {
+		queue = queue[idx+1:]
+	}
+
+	attrs := []attribute.KeyValue{
+		semconv.MessagingSystem("AmazonSQS"),
+		semconv.MessagingOperationProcess,
+		semconv.MessagingDestinationName(queue),
+	}
+	if msg.MessageId != nil {
+		attrs = append(attrs, semconv.MessagingMessageID(*msg.MessageId))
+	}
+
+	spanOpts := []trace.SpanStartOption{
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(attrs...),
+	}
+	producerCtx := cfg.TextMapPropagator.Extract(context.Background(), SQSMessageAttributeCarrier(msg.MessageAttributes))
+	if sc := trace.SpanContextFromContext(producerCtx); sc.IsValid() {
+		spanOpts = append(spanOpts, trace.WithLinks(trace.Link{SpanContext: sc}))
+	}
+
+	return cfg.tracer().Start(ctx, queue+" process", spanOpts...)
+}
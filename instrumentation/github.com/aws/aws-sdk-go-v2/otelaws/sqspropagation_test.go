@@ -0,0 +1,142 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelaws
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/aws/smithy-go/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const testTraceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+func testSpanContext() context.Context {
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+		SpanID:     trace.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+		TraceFlags: trace.FlagsSampled,
+	})
+	return trace.ContextWithSpanContext(context.Background(), sc)
+}
+
+// handlePropagation runs the propagation middleware added by register with
+// params and returns the parameters passed to the next handler.
+func handlePropagation(t *testing.T, register func(otelMiddlewares, *middleware.Stack) error, params interface{}) interface{} {
+	t.Helper()
+
+	stack := middleware.Stack{
+		Initialize: middleware.NewInitializeStep(),
+	}
+	m := otelMiddlewares{
+		propagator:      propagation.TraceContext{},
+		kinesisEnvelope: JSONKinesisRecordEnvelope{},
+	}
+	require.NoError(t, register(m, &stack))
+
+	var got interface{}
+	next := middleware.HandlerFunc(func(ctx context.Context, input interface{}) (interface{}, middleware.Metadata, error) {
+		return nil, middleware.Metadata{}, nil
+	})
+	err := stack.Initialize.Add(middleware.InitializeMiddlewareFunc("capture", func(
+		ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (
+		middleware.InitializeOutput, middleware.Metadata, error,
+	) {
+		got = in.Parameters
+		return next.HandleInitialize(ctx, in)
+	}), middleware.After)
+	require.NoError(t, err)
+
+	_, _, err = stack.Initialize.HandleMiddleware(testSpanContext(), params, next)
+	require.NoError(t, err)
+	return got
+}
+
+func handleSQSPropagation(t *testing.T, params interface{}) interface{} {
+	t.Helper()
+	return handlePropagation(t, otelMiddlewares.sqsPropagationMiddleware, params)
+}
+
+func TestSQSPropagationSendMessage(t *testing.T) {
+	input := &sqs.SendMessageInput{
+		QueueUrl:    aws.String("https://sqs.us-east-1.amazonaws.com/123456789012/queue"),
+		MessageBody: aws.String("body"),
+		MessageAttributes: map[string]types.MessageAttributeValue{
+			"user": {DataType: aws.String("String"), StringValue: aws.String("value")},
+		},
+	}
+
+	got, ok := handleSQSPropagation(t, input).(*sqs.SendMessageInput)
+	require.True(t, ok)
+	carrier := SQSMessageAttributeCarrier(got.MessageAttributes)
+	assert.Equal(t, testTraceparent, carrier.Get("traceparent"))
+	assert.Equal(t, "value", carrier.Get("user"))
+	assert.Equal(t, "String", *got.MessageAttributes["traceparent"].DataType)
+
+	// The input of the caller is not modified.
+	assert.Len(t, input.MessageAttributes, 1)
+}
+
+func TestSQSPropagationSendMessageAttributeLimit(t *testing.T) {
+	attrs := map[string]types.MessageAttributeValue{}
+	for i := 0; i < sqsMaxMessageAttributes; i++ {
+		attrs[fmt.Sprintf("attr%d", i)] = types.MessageAttributeValue{DataType: aws.String("String"), StringValue: aws.String("value")}
+	}
+
+	got, ok := handleSQSPropagation(t, &sqs.SendMessageInput{MessageAttributes: attrs}).(*sqs.SendMessageInput)
+	require.True(t, ok)
+	assert.Len(t, got.MessageAttributes, sqsMaxMessageAttributes)
+	assert.NotContains(t, got.MessageAttributes, "traceparent")
+}
+
+func TestSQSPropagationSendMessageBatch(t *testing.T) {
+	input := &sqs.SendMessageBatchInput{
+		Entries: []types.SendMessageBatchRequestEntry{
+			{Id: aws.String("1")},
+			{Id: aws.String("2")},
+		},
+	}
+
+	got, ok := handleSQSPropagation(t, input).(*sqs.SendMessageBatchInput)
+	require.True(t, ok)
+	require.Len(t, got.Entries, 2)
+	for _, entry := range got.Entries {
+		assert.Equal(t, testTraceparent, SQSMessageAttributeCarrier(entry.MessageAttributes).Get("traceparent"))
+	}
+	assert.Nil(t, input.Entries[0].MessageAttributes)
+}
+
+func TestSQSPropagationReceiveMessage(t *testing.T) {
+	got, ok := handleSQSPropagation(t, &sqs.ReceiveMessageInput{
+		MessageAttributeNames: []string{"user", "traceparent"},
+	}).(*sqs.ReceiveMessageInput)
+	require.True(t, ok)
+	assert.Equal(t, []string{"user", "traceparent", "tracestate"}, got.MessageAttributeNames)
+
+	got, ok = handleSQSPropagation(t, &sqs.ReceiveMessageInput{
+		MessageAttributeNames: []string{"All"},
+	}).(*sqs.ReceiveMessageInput)
+	require.True(t, ok)
+	assert.Equal(t, []string{"All"}, got.MessageAttributeNames)
+}
@@ -16,11 +16,14 @@ package test
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
 	"github.com/aws/aws-sdk-go-v2/service/route53"
 	"github.com/aws/aws-sdk-go-v2/service/route53/types"
 	smithyauth "github.com/aws/smithy-go/auth"
@@ -31,6 +34,8 @@ import (
 	"go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-sdk-go-v2/otelaws"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	"go.opentelemetry.io/otel/trace"
@@ -161,3 +166,176 @@ func TestAppendMiddlewares(t *testing.T) {
 		srv.Close()
 	}
 }
+
+func TestAppendMiddlewaresMetrics(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			if requests == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?>
+		<ChangeResourceRecordSetsResponse>
+			<ChangeInfo>
+				<Id>mockID</Id>
+			</ChangeInfo>
+		</ChangeResourceRecordSetsResponse>`))
+			if err != nil {
+				t.Fatal(err)
+			}
+		}))
+	defer srv.Close()
+
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	svc := route53.New(route53.Options{
+		Region:             "us-west-2",
+		BaseEndpoint:       &srv.URL,
+		AuthSchemeResolver: &route53AuthResolver{},
+		AuthSchemes: []smithyhttp.AuthScheme{
+			smithyhttp.NewAnonymousScheme(),
+		},
+		Retryer: retry.NewStandard(func(o *retry.StandardOptions) {
+			o.Backoff = retry.BackoffDelayerFunc(func(int, error) (time.Duration, error) {
+				return 0, nil
+			})
+		}),
+	})
+
+	_, err := svc.ChangeResourceRecordSets(context.Background(), &route53.ChangeResourceRecordSetsInput{
+		ChangeBatch: &types.ChangeBatch{
+			Changes: []types.Change{},
+		},
+		HostedZoneId: aws.String("zone"),
+	}, func(options *route53.Options) {
+		otelaws.AppendMiddlewares(
+			&options.APIOptions, otelaws.WithMeterProvider(provider))
+	})
+	require.NoError(t, err)
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	assert.Equal(t, otelaws.ScopeName, rm.ScopeMetrics[0].Scope.Name)
+
+	want := attribute.NewSet(
+		attribute.String("rpc.system", "aws-api"),
+		attribute.String("rpc.service", "Route 53"),
+		attribute.String("rpc.method", "ChangeResourceRecordSets"),
+		attribute.String("aws.region", "us-west-2"),
+		attribute.Int("http.status_code", http.StatusOK),
+	)
+	metrics := map[string]metricdata.Metrics{}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		metrics[m.Name] = m
+	}
+
+	duration, ok := metrics["rpc.client.duration"].Data.(metricdata.Histogram[float64])
+	require.True(t, ok)
+	require.Len(t, duration.DataPoints, 1)
+	assert.Equal(t, want, duration.DataPoints[0].Attributes)
+	assert.Equal(t, uint64(1), duration.DataPoints[0].Count)
+
+	attempts, ok := metrics["rpc.client.attempts"].Data.(metricdata.Histogram[int64])
+	require.True(t, ok)
+	require.Len(t, attempts.DataPoints, 1)
+	assert.Equal(t, want, attempts.DataPoints[0].Attributes)
+	assert.Equal(t, int64(2), attempts.DataPoints[0].Sum)
+
+	assert.NotContains(t, metrics, "rpc.client.errors")
+}
+
+func TestAppendMiddlewaresAttemptSpans(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			w.Header().Set("X-Amzn-Requestid", fmt.Sprintf("request-%d", requests))
+			if requests == 1 {
+				w.WriteHeader(http.StatusBadRequest)
+				_, err := w.Write([]byte(`<?xml version="1.0"?>
+		<ErrorResponse xmlns="http://route53.amazonaws.com/doc/2016-09-07/">
+		  <Error>
+		    <Type>Sender</Type>
+		    <Code>Throttling</Code>
+		    <Message>Rate exceeded</Message>
+		  </Error>
+		  <RequestId>request-1</RequestId>
+		</ErrorResponse>`))
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?>
+		<ChangeResourceRecordSetsResponse>
+			<ChangeInfo>
+				<Id>mockID</Id>
+			</ChangeInfo>
+		</ChangeResourceRecordSetsResponse>`))
+			if err != nil {
+				t.Fatal(err)
+			}
+		}))
+	defer srv.Close()
+
+	sr := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+
+	svc := route53.New(route53.Options{
+		Region:             "us-west-2",
+		BaseEndpoint:       &srv.URL,
+		AuthSchemeResolver: &route53AuthResolver{},
+		AuthSchemes: []smithyhttp.AuthScheme{
+			smithyhttp.NewAnonymousScheme(),
+		},
+		Retryer: retry.NewStandard(func(o *retry.StandardOptions) {
+			o.Backoff = retry.BackoffDelayerFunc(func(int, error) (time.Duration, error) {
+				return 0, nil
+			})
+		}),
+	})
+
+	_, err := svc.ChangeResourceRecordSets(context.Background(), &route53.ChangeResourceRecordSetsInput{
+		ChangeBatch: &types.ChangeBatch{
+			Changes: []types.Change{},
+		},
+		HostedZoneId: aws.String("zone"),
+	}, func(options *route53.Options) {
+		otelaws.AppendMiddlewares(
+			&options.APIOptions, otelaws.WithTracerProvider(provider), otelaws.WithAttemptSpans())
+	})
+	require.NoError(t, err)
+
+	spans := sr.Ended()
+	require.Len(t, spans, 3)
+	first, second, operation := spans[0], spans[1], spans[2]
+
+	for _, s := range spans {
+		assert.Equal(t, "Route 53.ChangeResourceRecordSets", s.Name())
+		assert.Equal(t, trace.SpanKindClient, s.SpanKind())
+	}
+	assert.Equal(t, operation.SpanContext().SpanID(), first.Parent().SpanID())
+	assert.Equal(t, operation.SpanContext().SpanID(), second.Parent().SpanID())
+
+	assert.Equal(t, codes.Error, first.Status().Code)
+	assert.Contains(t, first.Attributes(), attribute.Int("aws.attempt", 1))
+	assert.Contains(t, first.Attributes(), attribute.Int("http.status_code", http.StatusBadRequest))
+	assert.Contains(t, first.Attributes(), attribute.String("aws.error_code", "Throttling"))
+	assert.Contains(t, first.Attributes(), attribute.Bool("aws.throttled", true))
+	assert.Contains(t, first.Attributes(), attribute.String("aws.request_id", "request-1"))
+
+	assert.Equal(t, codes.Unset, second.Status().Code)
+	assert.Contains(t, second.Attributes(), attribute.Int("aws.attempt", 2))
+	assert.Contains(t, second.Attributes(), attribute.Int("http.status_code", http.StatusOK))
+	assert.Contains(t, second.Attributes(), attribute.String("aws.request_id", "request-2"))
+
+	assert.Equal(t, codes.Unset, operation.Status().Code)
+	assert.Contains(t, operation.Attributes(), attribute.Int("http.status_code", http.StatusOK))
+	assert.Contains(t, operation.Attributes(), attribute.String("aws.request_id", "request-2"))
+}
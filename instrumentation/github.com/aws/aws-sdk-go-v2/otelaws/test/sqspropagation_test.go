@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-sdk-go-v2/otelaws"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestStartSQSProcessSpan(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	propagator := propagation.TraceContext{}
+
+	producerCtx, producer := provider.Tracer("test").Start(context.Background(), "producer")
+	producer.End()
+	carrier := otelaws.SQSMessageAttributeCarrier{}
+	propagator.Inject(producerCtx, carrier)
+
+	msg := types.Message{
+		MessageId:         aws.String("message-id"),
+		MessageAttributes: carrier,
+	}
+
+	ctx, receive := provider.Tracer("test").Start(context.Background(), "receive")
+	_, span := otelaws.StartSQSProcessSpan(ctx, "https://sqs.us-east-1.amazonaws.com/123456789012/test-queue", msg,
+		otelaws.WithTracerProvider(provider),
+		otelaws.WithTextMapPropagator(propagator),
+	)
+	span.End()
+	receive.End()
+
+	spans := sr.Ended()
+	require.Len(t, spans, 3)
+	process := spans[1]
+
+	assert.Equal(t, "test-queue process", process.Name())
+	assert.Equal(t, trace.SpanKindConsumer, process.SpanKind())
+	assert.Equal(t, receive.SpanContext().SpanID(), process.Parent().SpanID())
+	require.Len(t, process.Links(), 1)
+	assert.Equal(t, producer.SpanContext().TraceID(), process.Links()[0].SpanContext.TraceID())
+	assert.Equal(t, producer.SpanContext().SpanID(), process.Links()[0].SpanContext.SpanID())
+
+	attrs := process.Attributes()
+	assert.Contains(t, attrs, attribute.String("messaging.system", "AmazonSQS"))
+	assert.Contains(t, attrs, attribute.String("messaging.operation", "process"))
+	assert.Contains(t, attrs, attribute.String("messaging.destination.name", "test-queue"))
+	assert.Contains(t, attrs, attribute.String("messaging.message.id", "message-id"))
+}
+
+func TestStartSQSProcessSpanWithoutContext(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+
+	_, span := otelaws.StartSQSProcessSpan(context.Background(), "test-queue", types.Message{},
+		otelaws.WithTracerProvider(provider),
+		otelaws.WithTextMapPropagator(propagation.TraceContext{}),
+	)
+	span.End()
+
+	spans := sr.Ended()
+	require.Len(t, spans, 1)
+	assert.Empty(t, spans[0].Links())
+	assert.Equal(t, "test-queue process", spans[0].Name())
+}
@@ -0,0 +1,145 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelmongo // import "go.opentelemetry.io/contrib/instrumentation/go.mongodb.org/mongo-driver/mongo/otelmongo"
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strings"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TraceComment returns a MongoDB command comment carrying the W3C trace
+// context of the span in ctx, in the sqlcommenter format:
+// traceparent='00-<trace-id>-<span-id>-<flags>'. A tracestate is appended when
+// present. An empty string is returned if ctx does not contain a valid span
+// context.
+//
+// As specified by sqlcommenter, the keys and values are percent-encoded, all
+// the bytes but the unreserved characters of RFC 3986 being encoded, and the
+// values are enclosed in single quotes. The tracestate "rojo=00f067aa0ba902b7"
+// is serialized as tracestate='rojo%3D00f067aa0ba902b7'.
+func TraceComment(ctx context.Context) string {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return ""
+	}
+
+	carrier := propagation.MapCarrier{}
+	propagation.TraceContext{}.Inject(ctx, carrier)
+	keys := carrier.Keys()
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, sqlcommenterEscape(k)+"='"+sqlcommenterEscape(carrier.Get(k))+"'")
+	}
+	return strings.Join(pairs, ",")
+}
+
+// sqlcommenterEscape returns s percent-encoded and with its single quotes
+// escaped, as sqlcommenter serializes keys and values.
+func sqlcommenterEscape(s string) string {
+	const hex = "0123456789ABCDEF"
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9',
+			c == '-', c == '.', c == '_', c == '~':
+			b.WriteByte(c)
+		default:
+			b.WriteByte('%')
+			b.WriteByte(hex[c>>4])
+			b.WriteByte(hex[c&0x0f])
+		}
+	}
+	// Percent-encoding leaves no single quote to escape with a backslash.
+	return b.String()
+}
+
+// Commenter sets the TraceComment of the current span as the comment of
+// MongoDB operations, so the database profiler and the server logs can be
+// correlated with traces.
+//
+// The event.CommandMonitor returned by NewMonitor only observes the commands
+// sent by the driver, it cannot modify them. The comment is instead set on
+// the options of the operations before they are run, e.g.:
+//
+//	opts := options.Find()
+//	commenter.SetComment(ctx, opts)
+//	cursor, err := collection.Find(ctx, filter, opts)
+type Commenter struct{}
+
+// NewCommenter returns a Commenter.
+func NewCommenter() *Commenter {
+	return &Commenter{}
+}
+
+// SetComment sets the TraceComment of ctx as the comment of opts, the
+// options of a MongoDB operation, e.g. *options.FindOptions or
+// *options.InsertOneOptions, and reports whether it did.
+//
+// The comment is only set if the Commenter is not nil, ctx contains a valid
+// span context, the operation supports comments, i.e. its options have a
+// Comment field, and no comment is set yet: a user-supplied comment is never
+// overwritten. Comments are supported by the servers for all operations from
+// MongoDB 4.4 onward, and for the read operations only before.
+func (c *Commenter) SetComment(ctx context.Context, opts interface{}) bool {
+	if c == nil {
+		return false
+	}
+
+	field := commentField(opts)
+	if !field.IsValid() || !field.IsZero() {
+		return false
+	}
+	comment := TraceComment(ctx)
+	if comment == "" {
+		return false
+	}
+
+	switch field.Kind() {
+	case reflect.Pointer:
+		// Comment *string
+		field.Set(reflect.ValueOf(&comment))
+	default:
+		// Comment interface{}
+		field.Set(reflect.ValueOf(comment))
+	}
+	return true
+}
+
+// commentField returns the settable Comment field of the options opts, or
+// the zero Value if the operation does not support comments.
+func commentField(opts interface{}) reflect.Value {
+	v := reflect.ValueOf(opts)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return reflect.Value{}
+	}
+	field := v.Elem().FieldByName("Comment")
+	if !field.IsValid() || !field.CanSet() {
+		return reflect.Value{}
+	}
+	switch {
+	case field.Kind() == reflect.Pointer && field.Type().Elem().Kind() == reflect.String:
+		return field
+	case field.Kind() == reflect.Interface && field.Type().NumMethod() == 0:
+		return field
+	}
+	return reflect.Value{}
+}
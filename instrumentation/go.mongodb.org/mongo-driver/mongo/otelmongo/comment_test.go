@@ -0,0 +1,123 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelmongo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+const traceparentComment = "traceparent='00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01'"
+
+func spanContextCtx(t *testing.T, state string) context.Context {
+	t.Helper()
+	ts, err := trace.ParseTraceState(state)
+	require.NoError(t, err)
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+		SpanID:     trace.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+		TraceFlags: trace.FlagsSampled,
+		TraceState: ts,
+	})
+	return trace.ContextWithSpanContext(context.Background(), sc)
+}
+
+func TestTraceComment(t *testing.T) {
+	assert.Equal(t, "", TraceComment(context.Background()))
+
+	ctx := spanContextCtx(t, "")
+	assert.Equal(t, traceparentComment, TraceComment(ctx))
+
+	// The example of the sqlcommenter specification.
+	ctx = spanContextCtx(t, "congo=t61rcWkgMzE,rojo=00f067aa0ba902b7")
+	assert.Equal(t, traceparentComment+",tracestate='congo%3Dt61rcWkgMzE%2Crojo%3D00f067aa0ba902b7'", TraceComment(ctx))
+}
+
+func TestSQLCommenterEscape(t *testing.T) {
+	for in, want := range map[string]string{
+		"":                   "",
+		"00-abc-01":          "00-abc-01",
+		"a b":                "a%20b",
+		"it's":               "it%27s",
+		"k=v,k2=v2":          "k%3Dv%2Ck2%3Dv2",
+		"~_.-/+":             "~_.-%2F%2B",
+		"café":               "caf%C3%A9",
+		"route='/param*d'":   "route%3D%27%2Fparam%2Ad%27",
+		"select * from foo;": "select%20%2A%20from%20foo%3B",
+	} {
+		assert.Equal(t, want, sqlcommenterEscape(in), in)
+	}
+}
+
+func TestCommenterNil(t *testing.T) {
+	ctx := spanContextCtx(t, "")
+	opts := options.Find()
+
+	var c *Commenter
+	assert.False(t, c.SetComment(ctx, opts))
+	assert.Nil(t, opts.Comment)
+}
+
+func TestCommenterSetComment(t *testing.T) {
+	c := NewCommenter()
+	ctx := spanContextCtx(t, "")
+
+	find := options.Find()
+	assert.True(t, c.SetComment(ctx, find))
+	require.NotNil(t, find.Comment)
+	assert.Equal(t, traceparentComment, *find.Comment)
+
+	insert := options.InsertOne()
+	assert.True(t, c.SetComment(ctx, insert))
+	assert.Equal(t, traceparentComment, insert.Comment)
+
+	// No span context.
+	update := options.Update()
+	assert.False(t, c.SetComment(context.Background(), update))
+	assert.Nil(t, update.Comment)
+}
+
+func TestCommenterKeepsUserComment(t *testing.T) {
+	c := NewCommenter()
+	ctx := spanContextCtx(t, "")
+
+	find := options.Find().SetComment("user comment")
+	assert.False(t, c.SetComment(ctx, find))
+	assert.Equal(t, "user comment", *find.Comment)
+
+	doc := bson.D{{Key: "user", Value: "comment"}}
+	del := options.Delete().SetComment(doc)
+	assert.False(t, c.SetComment(ctx, del))
+	assert.Equal(t, doc, del.Comment)
+}
+
+func TestCommenterUnsupportedOperation(t *testing.T) {
+	c := NewCommenter()
+	ctx := spanContextCtx(t, "")
+
+	// Index creation does not support comments.
+	assert.False(t, c.SetComment(ctx, options.CreateIndexes()))
+	assert.False(t, c.SetComment(ctx, nil))
+	assert.False(t, c.SetComment(ctx, options.FindOptions{}))
+	assert.False(t, c.SetComment(ctx, (*options.FindOptions)(nil)))
+	assert.False(t, c.SetComment(ctx, &struct{ Comment int }{}))
+}
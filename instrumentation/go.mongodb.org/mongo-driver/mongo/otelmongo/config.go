@@ -16,6 +16,8 @@ package otelmongo // import "go.opentelemetry.io/contrib/instrumentation/go.mong
 
 import (
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -25,17 +27,27 @@ const ScopeName = "go.opentelemetry.io/contrib/instrumentation/go.mongodb.org/mo
 // config is used to configure the mongo tracer.
 type config struct {
 	TracerProvider trace.TracerProvider
+	MeterProvider  metric.MeterProvider
 
 	Tracer trace.Tracer
+	Meter  metric.Meter
 
 	CommandAttributeDisabled bool
+	CommandAttributeMaxSize  int
+	CommandSanitizer         CommandSanitizer
+
+	operationDuration metric.Float64Histogram
+	operationErrors   metric.Int64Counter
 }
 
 // newConfig returns a config with all Options set.
 func newConfig(opts ...Option) config {
 	cfg := config{
 		TracerProvider:           otel.GetTracerProvider(),
+		MeterProvider:            otel.GetMeterProvider(),
 		CommandAttributeDisabled: true,
+		CommandAttributeMaxSize:  defaultCommandAttributeMaxSize,
+		CommandSanitizer:         sanitizeCommand,
 	}
 	for _, opt := range opts {
 		opt.apply(&cfg)
@@ -45,6 +57,31 @@ func newConfig(opts ...Option) config {
 		ScopeName,
 		trace.WithInstrumentationVersion(Version()),
 	)
+
+	cfg.Meter = cfg.MeterProvider.Meter(
+		ScopeName,
+		metric.WithInstrumentationVersion(Version()),
+		metric.WithSchemaURL(semconv.SchemaURL),
+	)
+
+	var err error
+	cfg.operationDuration, err = cfg.Meter.Float64Histogram("db.client.operation.duration",
+		metric.WithDescription("Measures the duration of MongoDB commands."),
+		metric.WithUnit("s"),
+		// The buckets advised by the semantic conventions for durations in
+		// seconds, the SDK default ones being tuned for milliseconds.
+		metric.WithExplicitBucketBoundaries(0.005, 0.01, 0.025, 0.05, 0.075, 0.1, 0.25, 0.5, 0.75, 1, 2.5, 5, 7.5, 10))
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	cfg.operationErrors, err = cfg.Meter.Int64Counter("db.client.operation.errors",
+		metric.WithDescription("Measures the number of failed MongoDB commands."),
+		metric.WithUnit("{error}"))
+	if err != nil {
+		otel.Handle(err)
+	}
+
 	return cfg
 }
 
@@ -69,6 +106,16 @@ func WithTracerProvider(provider trace.TracerProvider) Option {
 	})
 }
 
+// WithMeterProvider specifies a meter provider to use for creating a meter.
+// If none is specified, the global provider is used.
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return optionFunc(func(cfg *config) {
+		if provider != nil {
+			cfg.MeterProvider = provider
+		}
+	})
+}
+
 // WithCommandAttributeDisabled specifies if the MongoDB command is added as an attribute to Spans or not.
 // This is disabled by default and the MongoDB command will not be added as an attribute
 // to Spans if this option is not provided.
@@ -77,3 +124,25 @@ func WithCommandAttributeDisabled(disabled bool) Option {
 		cfg.CommandAttributeDisabled = disabled
 	})
 }
+
+// WithCommandAttributeMaxSize specifies the maximum size, in bytes, of the
+// MongoDB command added as an attribute to Spans. Longer commands are
+// truncated. If none is specified, commands are truncated to 4096 bytes. A
+// size less than or equal to zero disables truncation.
+func WithCommandAttributeMaxSize(size int) Option {
+	return optionFunc(func(cfg *config) {
+		cfg.CommandAttributeMaxSize = size
+	})
+}
+
+// WithCommandSanitizer specifies the function used to convert the MongoDB
+// command into the value of the db.statement attribute. If none is
+// specified, the command is rendered as extended JSON with all literal
+// values replaced with "?".
+func WithCommandSanitizer(sanitizer CommandSanitizer) Option {
+	return optionFunc(func(cfg *config) {
+		if sanitizer != nil {
+			cfg.CommandSanitizer = sanitizer
+		}
+	})
+}
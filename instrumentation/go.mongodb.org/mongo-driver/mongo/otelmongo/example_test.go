@@ -50,3 +50,26 @@ func Example() {
 		panic(err)
 	}
 }
+
+func ExampleCommenter() {
+	opts := options.Client()
+	opts.Monitor = otelmongo.NewMonitor()
+	opts.ApplyURI("mongodb://localhost:27017")
+	client, err := mongo.Connect(context.Background(), opts)
+	if err != nil {
+		panic(err)
+	}
+	inventory := client.Database("example").Collection("inventory")
+
+	// The trace context of ctx is set as the comment of the find command,
+	// which is recorded by the database profiler.
+	ctx := context.Background()
+	commenter := otelmongo.NewCommenter()
+	findOpts := options.Find()
+	commenter.SetComment(ctx, findOpts)
+	cursor, err := inventory.Find(ctx, bson.D{{Key: "qty", Value: 100}}, findOpts)
+	if err != nil {
+		panic(err)
+	}
+	defer cursor.Close(ctx)
+}
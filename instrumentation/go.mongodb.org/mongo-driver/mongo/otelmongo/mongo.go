@@ -23,6 +23,7 @@ import (
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
 	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
 	"go.opentelemetry.io/otel/trace"
 
@@ -35,9 +36,16 @@ type spanKey struct {
 	RequestID    int64
 }
 
+// command holds the state of an in-flight MongoDB command.
+type command struct {
+	span trace.Span
+	// attrs are the attributes recorded on the command metrics.
+	attrs []attribute.KeyValue
+}
+
 type monitor struct {
 	sync.Mutex
-	spans map[spanKey]trace.Span
+	spans map[spanKey]command
 	cfg   config
 }
 
@@ -46,20 +54,24 @@ func (m *monitor) Started(ctx context.Context, evt *event.CommandStartedEvent) {
 
 	hostname, port := peerInfo(evt)
 
-	attrs := []attribute.KeyValue{
+	metricAttrs := []attribute.KeyValue{
 		semconv.DBSystemMongoDB,
 		semconv.DBOperation(evt.CommandName),
 		semconv.DBName(evt.DatabaseName),
 		semconv.NetPeerName(hostname),
 		semconv.NetPeerPort(port),
-		semconv.NetTransportTCP,
-	}
-	if !m.cfg.CommandAttributeDisabled {
-		attrs = append(attrs, semconv.DBStatement(sanitizeCommand(evt.Command)))
 	}
 	if collection, err := extractCollection(evt); err == nil && collection != "" {
 		spanName = collection + "."
-		attrs = append(attrs, semconv.DBMongoDBCollection(collection))
+		metricAttrs = append(metricAttrs, semconv.DBMongoDBCollection(collection))
+	}
+
+	attrs := make([]attribute.KeyValue, 0, len(metricAttrs)+2)
+	attrs = append(attrs, metricAttrs...)
+	attrs = append(attrs, semconv.NetTransportTCP)
+	if !m.cfg.CommandAttributeDisabled {
+		statement := truncate(m.cfg.CommandSanitizer(evt.Command), m.cfg.CommandAttributeMaxSize)
+		attrs = append(attrs, semconv.DBStatement(statement))
 	}
 	spanName += evt.CommandName
 	opts := []trace.SpanStartOption{
@@ -72,25 +84,29 @@ func (m *monitor) Started(ctx context.Context, evt *event.CommandStartedEvent) {
 		RequestID:    evt.RequestID,
 	}
 	m.Lock()
-	m.spans[key] = span
+	m.spans[key] = command{span: span, attrs: metricAttrs}
 	m.Unlock()
 }
 
 func (m *monitor) Succeeded(ctx context.Context, evt *event.CommandSucceededEvent) {
-	m.Finished(&evt.CommandFinishedEvent, nil)
+	m.finished(ctx, &evt.CommandFinishedEvent, nil)
 }
 
 func (m *monitor) Failed(ctx context.Context, evt *event.CommandFailedEvent) {
-	m.Finished(&evt.CommandFinishedEvent, fmt.Errorf("%s", evt.Failure))
+	m.finished(ctx, &evt.CommandFinishedEvent, fmt.Errorf("%s", evt.Failure))
 }
 
 func (m *monitor) Finished(evt *event.CommandFinishedEvent, err error) {
+	m.finished(context.Background(), evt, err)
+}
+
+func (m *monitor) finished(ctx context.Context, evt *event.CommandFinishedEvent, err error) {
 	key := spanKey{
 		ConnectionID: evt.ConnectionID,
 		RequestID:    evt.RequestID,
 	}
 	m.Lock()
-	span, ok := m.spans[key]
+	cmd, ok := m.spans[key]
 	if ok {
 		delete(m.spans, key)
 	}
@@ -99,18 +115,14 @@ func (m *monitor) Finished(evt *event.CommandFinishedEvent, err error) {
 		return
 	}
 
+	o := metric.WithAttributes(cmd.attrs...)
+	m.cfg.operationDuration.Record(ctx, evt.Duration.Seconds(), o)
 	if err != nil {
-		span.SetStatus(codes.Error, err.Error())
+		m.cfg.operationErrors.Add(ctx, 1, o)
+		cmd.span.SetStatus(codes.Error, err.Error())
 	}
 
-	span.End()
-}
-
-// TODO sanitize values where possible, then reenable `db.statement` span attributes default.
-// TODO limit maximum size.
-func sanitizeCommand(command bson.Raw) string {
-	b, _ := bson.MarshalExtJSON(command, false, false)
-	return string(b)
+	cmd.span.End()
 }
 
 // extractCollection extracts the collection for the given mongodb command event.
@@ -136,7 +148,7 @@ func extractCollection(evt *event.CommandStartedEvent) (string, error) {
 func NewMonitor(opts ...Option) *event.CommandMonitor {
 	cfg := newConfig(opts...)
 	m := &monitor{
-		spans: make(map[spanKey]trace.Span),
+		spans: make(map[spanKey]command),
 		cfg:   cfg,
 	}
 	return &event.CommandMonitor{
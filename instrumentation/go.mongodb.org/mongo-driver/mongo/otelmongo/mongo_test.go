@@ -0,0 +1,136 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelmongo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func mustMarshal(t *testing.T, doc interface{}) bson.Raw {
+	t.Helper()
+	b, err := bson.Marshal(doc)
+	require.NoError(t, err)
+	return b
+}
+
+func TestMonitorMetrics(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+
+	m := NewMonitor(WithTracerProvider(tp), WithMeterProvider(mp))
+	ctx := context.Background()
+
+	m.Started(ctx, &event.CommandStartedEvent{
+		Command:      mustMarshal(t, bson.D{{Key: "insert", Value: "test-collection"}}),
+		DatabaseName: "test-database",
+		CommandName:  "insert",
+		RequestID:    1,
+		ConnectionID: "localhost:27017[-1]",
+	})
+	m.Succeeded(ctx, &event.CommandSucceededEvent{
+		CommandFinishedEvent: event.CommandFinishedEvent{
+			Duration:     2 * time.Second,
+			CommandName:  "insert",
+			DatabaseName: "test-database",
+			RequestID:    1,
+			ConnectionID: "localhost:27017[-1]",
+		},
+	})
+
+	m.Started(ctx, &event.CommandStartedEvent{
+		Command:      mustMarshal(t, bson.D{{Key: "insert", Value: "test-collection"}}),
+		DatabaseName: "test-database",
+		CommandName:  "insert",
+		RequestID:    2,
+		ConnectionID: "localhost:27017[-1]",
+	})
+	m.Failed(ctx, &event.CommandFailedEvent{
+		CommandFinishedEvent: event.CommandFinishedEvent{
+			Duration:     time.Second,
+			CommandName:  "insert",
+			DatabaseName: "test-database",
+			RequestID:    2,
+			ConnectionID: "localhost:27017[-1]",
+		},
+		Failure: "duplicate key",
+	})
+
+	assert.Len(t, sr.Ended(), 2)
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(ctx, &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	assert.Equal(t, ScopeName, rm.ScopeMetrics[0].Scope.Name)
+
+	attrs := attribute.NewSet(
+		attribute.String("db.system", "mongodb"),
+		attribute.String("db.operation", "insert"),
+		attribute.String("db.name", "test-database"),
+		attribute.String("db.mongodb.collection", "test-collection"),
+		attribute.String("net.peer.name", "localhost"),
+		attribute.Int("net.peer.port", 27017),
+	)
+	want := metricdata.ScopeMetrics{
+		Scope: rm.ScopeMetrics[0].Scope,
+		Metrics: []metricdata.Metrics{
+			{
+				Name:        "db.client.operation.duration",
+				Description: "Measures the duration of MongoDB commands.",
+				Unit:        "s",
+				Data: metricdata.Histogram[float64]{
+					Temporality: metricdata.CumulativeTemporality,
+					DataPoints: []metricdata.HistogramDataPoint[float64]{
+						{
+							Attributes:   attrs,
+							Count:        2,
+							Bounds:       []float64{0.005, 0.01, 0.025, 0.05, 0.075, 0.1, 0.25, 0.5, 0.75, 1, 2.5, 5, 7.5, 10},
+							BucketCounts: []uint64{0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 1, 0, 0, 0, 0},
+							Min:          metricdata.NewExtrema(1.0),
+							Max:          metricdata.NewExtrema(2.0),
+							Sum:          3,
+						},
+					},
+				},
+			},
+			{
+				Name:        "db.client.operation.errors",
+				Description: "Measures the number of failed MongoDB commands.",
+				Unit:        "{error}",
+				Data: metricdata.Sum[int64]{
+					Temporality: metricdata.CumulativeTemporality,
+					IsMonotonic: true,
+					DataPoints:  []metricdata.DataPoint[int64]{{Attributes: attrs, Value: 1}},
+				},
+			},
+		},
+	}
+	metricdatatest.AssertEqual(t, want, rm.ScopeMetrics[0], metricdatatest.IgnoreTimestamp())
+}
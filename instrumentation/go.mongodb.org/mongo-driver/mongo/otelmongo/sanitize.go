@@ -0,0 +1,127 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelmongo // import "go.opentelemetry.io/contrib/instrumentation/go.mongodb.org/mongo-driver/mongo/otelmongo"
+
+import (
+	"unicode/utf8"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// defaultCommandAttributeMaxSize is the default maximum size, in bytes, of
+// the db.statement attribute.
+const defaultCommandAttributeMaxSize = 4096
+
+// maxBinarySize is the size, in bytes, above which binary values are dropped
+// from a sanitized command instead of being replaced with a placeholder.
+const maxBinarySize = 16
+
+// obfuscated is the placeholder replacing literal values in a sanitized
+// command.
+const obfuscated = "?"
+
+// CommandSanitizer returns the value of the db.statement attribute for a
+// MongoDB command. Implementations must not retain or modify command.
+type CommandSanitizer func(command bson.Raw) string
+
+// sanitizeCommand is the default CommandSanitizer. It returns the command as
+// extended JSON with every literal value replaced with "?", keeping the names
+// of the fields, the nesting of documents and arrays, and the name of the
+// collection the command applies to. Binary values larger than 16 bytes are
+// dropped.
+func sanitizeCommand(command bson.Raw) string {
+	doc, err := sanitizeDocument(command, true)
+	if err != nil {
+		return ""
+	}
+	b, err := bson.MarshalExtJSON(doc, false, false)
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+// sanitizeDocument returns doc with all literal values obfuscated. If
+// keepFirst is true and the first element of doc is a string, it is kept as
+// is: for commands this is the collection name.
+func sanitizeDocument(doc bson.Raw, keepFirst bool) (bson.D, error) {
+	elems, err := doc.Elements()
+	if err != nil {
+		return nil, err
+	}
+	out := make(bson.D, 0, len(elems))
+	for i, elem := range elems {
+		v := elem.Value()
+		if i == 0 && keepFirst && v.Type == bson.TypeString {
+			out = append(out, bson.E{Key: elem.Key(), Value: v.StringValue()})
+			continue
+		}
+		if isLargeBinary(v) {
+			continue
+		}
+		sv, err := sanitizeValue(v)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, bson.E{Key: elem.Key(), Value: sv})
+	}
+	return out, nil
+}
+
+func sanitizeValue(v bson.RawValue) (interface{}, error) {
+	switch v.Type {
+	case bson.TypeEmbeddedDocument:
+		return sanitizeDocument(v.Document(), false)
+	case bson.TypeArray:
+		values, err := v.Array().Values()
+		if err != nil {
+			return nil, err
+		}
+		out := make(bson.A, 0, len(values))
+		for _, value := range values {
+			if isLargeBinary(value) {
+				continue
+			}
+			sv, err := sanitizeValue(value)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, sv)
+		}
+		return out, nil
+	default:
+		return obfuscated, nil
+	}
+}
+
+func isLargeBinary(v bson.RawValue) bool {
+	if v.Type != bson.TypeBinary {
+		return false
+	}
+	_, data := v.Binary()
+	return len(data) > maxBinarySize
+}
+
+// truncate returns s shortened to at most size bytes without splitting a
+// UTF-8 encoded rune. A size less than or equal to zero disables truncation.
+func truncate(s string, size int) string {
+	if size <= 0 || len(s) <= size {
+		return s
+	}
+	for size > 0 && !utf8.RuneStart(s[size]) {
+		size--
+	}
+	return s[:size]
+}
@@ -0,0 +1,168 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelmongo
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/event"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestSanitizeCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		command bson.D
+		want    string
+	}{
+		{
+			name: "insert",
+			command: bson.D{
+				{Key: "insert", Value: "test-collection"},
+				{Key: "documents", Value: bson.A{
+					bson.D{{Key: "name", Value: "alice"}, {Key: "age", Value: 42}},
+				}},
+				{Key: "ordered", Value: true},
+			},
+			want: `{"insert":"test-collection","documents":[{"name":"?","age":"?"}],"ordered":"?"}`,
+		},
+		{
+			name: "find with operators",
+			command: bson.D{
+				{Key: "find", Value: "users"},
+				{Key: "filter", Value: bson.D{
+					{Key: "age", Value: bson.D{{Key: "$gt", Value: 21}}},
+					{Key: "tags", Value: bson.D{{Key: "$in", Value: bson.A{"a", "b"}}}},
+				}},
+			},
+			want: `{"find":"users","filter":{"age":{"$gt":"?"},"tags":{"$in":["?","?"]}}}`,
+		},
+		{
+			name: "non-string first element",
+			command: bson.D{
+				{Key: "ping", Value: 1},
+			},
+			want: `{"ping":"?"}`,
+		},
+		{
+			name: "binary",
+			command: bson.D{
+				{Key: "insert", Value: "files"},
+				{Key: "documents", Value: bson.A{
+					bson.D{
+						{Key: "id", Value: primitive.Binary{Subtype: 0x04, Data: make([]byte, 16)}},
+						{Key: "data", Value: primitive.Binary{Data: make([]byte, 1024)}},
+					},
+				}},
+			},
+			want: `{"insert":"files","documents":[{"id":"?"}]}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, sanitizeCommand(mustMarshal(t, tc.command)))
+		})
+	}
+}
+
+func TestSanitizeCommandInvalid(t *testing.T) {
+	assert.Equal(t, "", sanitizeCommand(bson.Raw{0x01}))
+}
+
+func TestTruncate(t *testing.T) {
+	assert.Equal(t, "abc", truncate("abc", 0))
+	assert.Equal(t, "abc", truncate("abc", 3))
+	assert.Equal(t, "ab", truncate("abc", 2))
+	// "é" is encoded with two bytes and must not be split.
+	assert.Equal(t, "a", truncate("aé", 2))
+	assert.Equal(t, "aé", truncate("aé", 3))
+}
+
+func TestCommandAttributeOptions(t *testing.T) {
+	command := bson.D{
+		{Key: "insert", Value: "test-collection"},
+		{Key: "documents", Value: bson.A{bson.D{{Key: "secret", Value: "value"}}}},
+	}
+
+	tests := []struct {
+		name string
+		opts []Option
+		want string
+	}{
+		{
+			name: "default",
+			want: `{"insert":"test-collection","documents":[{"secret":"?"}]}`,
+		},
+		{
+			name: "max size",
+			opts: []Option{WithCommandAttributeMaxSize(10)},
+			want: `{"insert":`,
+		},
+		{
+			name: "custom sanitizer",
+			opts: []Option{WithCommandSanitizer(func(bson.Raw) string {
+				return strings.Repeat("x", 5000)
+			})},
+			want: strings.Repeat("x", defaultCommandAttributeMaxSize),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			sr := tracetest.NewSpanRecorder()
+			tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+			opts := append([]Option{
+				WithTracerProvider(tp),
+				WithCommandAttributeDisabled(false),
+			}, tc.opts...)
+			m := NewMonitor(opts...)
+
+			ctx := context.Background()
+			m.Started(ctx, &event.CommandStartedEvent{
+				Command:      mustMarshal(t, command),
+				CommandName:  "insert",
+				RequestID:    1,
+				ConnectionID: "localhost:27017[-1]",
+			})
+			m.Succeeded(ctx, &event.CommandSucceededEvent{
+				CommandFinishedEvent: event.CommandFinishedEvent{
+					CommandName:  "insert",
+					RequestID:    1,
+					ConnectionID: "localhost:27017[-1]",
+				},
+			})
+
+			spans := sr.Ended()
+			if !assert.Len(t, spans, 1) {
+				return
+			}
+			var got string
+			for _, attr := range spans[0].Attributes() {
+				if attr.Key == "db.statement" {
+					got = attr.Value.AsString()
+				}
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -43,6 +43,22 @@ func (s *metadataSupplier) Set(key string, value string) {
 	s.metadata.Set(key, value)
 }
 
+// GetBinary returns the value associated with the passed key. gRPC decodes
+// the values of keys with the -bin suffix, so they can be binary.
+func (s *metadataSupplier) GetBinary(key string) []byte {
+	values := s.metadata.Get(key)
+	if len(values) == 0 {
+		return nil
+	}
+	return []byte(values[0])
+}
+
+// SetBinary stores the key-value pair. gRPC encodes the values of keys with
+// the -bin suffix, so propagators of binary headers can set them as is.
+func (s *metadataSupplier) SetBinary(key string, value []byte) {
+	s.metadata.Set(key, string(value))
+}
+
 func (s *metadataSupplier) Keys() []string {
 	out := make([]string, 0, len(*s.metadata))
 	for key := range *s.metadata {
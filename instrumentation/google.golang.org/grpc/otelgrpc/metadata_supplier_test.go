@@ -37,3 +37,14 @@ func TestMetadataSupplier(t *testing.T) {
 	assert.Equal(t, v1, "v1")
 	assert.Equal(t, v2, "v2")
 }
+
+func TestMetadataSupplierBinary(t *testing.T) {
+	md := metadata.MD{}
+	ms := &metadataSupplier{&md}
+
+	assert.Nil(t, ms.GetBinary("k-bin"))
+
+	ms.SetBinary("k-bin", []byte{0x00, 0xff})
+	assert.Equal(t, []byte{0x00, 0xff}, ms.GetBinary("k-bin"))
+	assert.Equal(t, []string{"\x00\xff"}, md.Get("k-bin"))
+}
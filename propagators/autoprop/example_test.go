@@ -15,6 +15,7 @@
 package autoprop_test
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"sort"
@@ -23,6 +24,7 @@ import (
 	"go.opentelemetry.io/contrib/propagators/b3"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func ExampleNewTextMapPropagator() {
@@ -110,3 +112,31 @@ func ExampleGetTextMapPropagator_custom() {
 	fmt.Println(prop.Fields())
 	// Output: [my-header-val]
 }
+
+func ExampleNewFailOpenTextMapPropagator() {
+	// Services may receive requests with headers of several formats that
+	// carry different traces. The fail-open TextMapPropagator uses the span
+	// context of the propagator with the highest priority and reports the
+	// conflict.
+	//
+	// The OTEL_PROPAGATORS environment variable, if set, takes precedence
+	// over the WithPropagators option.
+	_ = os.Unsetenv("OTEL_PROPAGATORS")
+	prop := autoprop.NewFailOpenTextMapPropagator(
+		autoprop.WithPropagators("tracecontext", "b3", "baggage"),
+		autoprop.WithPriority("tracecontext"),
+		autoprop.WithConflictHandler(func(c autoprop.Conflict) {
+			fmt.Println("conflict, using", c.Selected)
+		}),
+	)
+
+	carrier := propagation.MapCarrier{
+		"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"b3":          "80f198ee56343ba864fe8b2a57d3eff7-e457b5a2e4d86bd1-1",
+	}
+	ctx := prop.Extract(context.Background(), carrier)
+	fmt.Println(trace.SpanContextFromContext(ctx).TraceID())
+	// Output:
+	// conflict, using tracecontext
+	// 4bf92f3577b34da6a3ce929d0e0e4736
+}
@@ -0,0 +1,284 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package autoprop // import "go.opentelemetry.io/contrib/propagators/autoprop"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ScopeName is the instrumentation scope name of the metrics recorded by the
+// TextMapPropagator returned by NewFailOpenTextMapPropagator.
+const ScopeName = "go.opentelemetry.io/contrib/propagators/autoprop"
+
+// errPropagatorPanic is reported when a propagator panics.
+var errPropagatorPanic = errors.New("propagator panicked")
+
+// Conflict describes the span contexts of different spans extracted from the
+// same carrier by the propagators of a fail-open TextMapPropagator.
+type Conflict struct {
+	// Selected is the name of the propagator whose span context is used.
+	Selected string
+	// SpanContexts are the valid span contexts extracted by each
+	// propagator, including the selected one, keyed by propagator name.
+	SpanContexts map[string]trace.SpanContext
+}
+
+// config contains options for the fail-open TextMapPropagator.
+type config struct {
+	names           []string
+	priority        []string
+	conflictHandler func(Conflict)
+	meterProvider   metric.MeterProvider
+}
+
+// Option applies an option to the fail-open TextMapPropagator.
+type Option interface {
+	apply(*config)
+}
+
+type optionFunc func(*config)
+
+func (o optionFunc) apply(c *config) {
+	o(c)
+}
+
+// newConfig returns a config configured with all the passed Options.
+func newConfig(opts []Option) *config {
+	c := &config{
+		names:         []string{"tracecontext", "baggage"},
+		meterProvider: otel.GetMeterProvider(),
+	}
+	for _, o := range opts {
+		o.apply(c)
+	}
+	return c
+}
+
+// WithPropagators sets the names of the registered TextMapPropagators to
+// compose when the OTEL_PROPAGATORS environment variable is not set. By
+// default, tracecontext and baggage are composed.
+func WithPropagators(names ...string) Option {
+	return optionFunc(func(c *config) {
+		c.names = names
+	})
+}
+
+// WithPriority sets the names of the propagators whose span context is used
+// when extracted span contexts conflict, from the highest to the lowest
+// priority. Propagators that are not listed have a lower priority, the last
+// one of them having the highest, as with
+// propagation.NewCompositeTextMapPropagator. By default, the span context of
+// the last propagator is used.
+func WithPriority(names ...string) Option {
+	return optionFunc(func(c *config) {
+		c.priority = names
+	})
+}
+
+// WithConflictHandler sets a function called with every conflict of
+// extracted span contexts.
+func WithConflictHandler(h func(Conflict)) Option {
+	return optionFunc(func(c *config) {
+		c.conflictHandler = h
+	})
+}
+
+// WithMeterProvider specifies a meter provider to use for recording the
+// conflicts of extracted span contexts. If none is specified, the global
+// provider is used.
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return optionFunc(func(c *config) {
+		if provider != nil {
+			c.meterProvider = provider
+		}
+	})
+}
+
+// NewFailOpenTextMapPropagator returns a TextMapPropagator composed of the
+// registered TextMapPropagators named by the OTEL_PROPAGATORS environment
+// variable or, if it is not set, by the WithPropagators option.
+//
+// Unlike propagation.NewCompositeTextMapPropagator, the returned
+// TextMapPropagator:
+//   - recovers from panics of the propagators, reporting them with
+//     otel.Handle and continuing with the next propagator.
+//   - detects when propagators extract span contexts of different traces or
+//     spans from the same carrier. The span context of the propagator with
+//     the highest priority (see WithPriority) is used, and the conflict is
+//     passed to the WithConflictHandler function and counted by the
+//     propagator.extract.conflicts metric.
+//
+// Unknown names are reported with otel.Handle and ignored. If "none" is
+// included in the names, a no-op TextMapPropagator is returned.
+func NewFailOpenTextMapPropagator(opts ...Option) propagation.TextMapPropagator {
+	c := newConfig(opts)
+
+	names := c.names
+	if env := envNames(); env != nil {
+		names = env
+	}
+
+	members, isNone, err := lookup(names)
+	if isNone {
+		return propagation.NewCompositeTextMapPropagator()
+	}
+	if err != nil {
+		otel.Handle(err)
+	}
+	p := &failOpen{members: members, conflictHandler: c.conflictHandler}
+
+	p.ranks = make(map[string]int, len(p.members))
+	for i, m := range p.members {
+		// Unlisted propagators rank after the listed ones, the last one
+		// first.
+		p.ranks[m.name] = len(c.priority) + len(p.members) - 1 - i
+	}
+	for i, name := range c.priority {
+		if _, ok := p.ranks[name]; ok {
+			p.ranks[name] = i
+		}
+	}
+
+	meter := c.meterProvider.Meter(ScopeName)
+	p.conflicts, err = meter.Int64Counter(
+		"propagator.extract.conflicts",
+		metric.WithUnit("{conflict}"),
+		metric.WithDescription("Number of carriers from which propagators extracted conflicting span contexts"),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+	return p
+}
+
+// failOpen is a composite TextMapPropagator isolating its members from
+// each other.
+type failOpen struct {
+	members []namedPropagator
+	// ranks are the priorities of the members by name, the lowest being the
+	// highest priority.
+	ranks           map[string]int
+	conflictHandler func(Conflict)
+	conflicts       metric.Int64Counter
+}
+
+var _ propagation.TextMapPropagator = &failOpen{}
+
+// Inject set cross-cutting concerns for all members in the carrier.
+func (p *failOpen) Inject(ctx context.Context, carrier propagation.TextMapCarrier) {
+	for _, m := range p.members {
+		func() {
+			defer p.recover(m.name)
+			m.prop.Inject(ctx, carrier)
+		}()
+	}
+}
+
+// Extract reads cross-cutting concerns for all members from the carrier into
+// a Context, resolving conflicting span contexts.
+func (p *failOpen) Extract(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	var (
+		prev      = trace.SpanContextFromContext(ctx)
+		extracted map[string]trace.SpanContext
+		selected  string
+	)
+	for _, m := range p.members {
+		func() {
+			defer p.recover(m.name)
+			ctx = m.prop.Extract(ctx, carrier)
+		}()
+
+		sc := trace.SpanContextFromContext(ctx)
+		if !sc.IsValid() || sc.Equal(prev) {
+			continue
+		}
+		prev = sc
+		if extracted == nil {
+			extracted = make(map[string]trace.SpanContext, 1)
+		}
+		extracted[m.name] = sc
+		if selected == "" || p.ranks[m.name] < p.ranks[selected] {
+			selected = m.name
+		}
+	}
+	if len(extracted) < 2 || !conflicting(extracted) {
+		if selected != "" {
+			ctx = trace.ContextWithSpanContext(ctx, extracted[selected])
+		}
+		return ctx
+	}
+
+	if p.conflicts != nil {
+		p.conflicts.Add(ctx, 1, metric.WithAttributes(attribute.String("propagator", selected)))
+	}
+	if p.conflictHandler != nil {
+		func() {
+			defer p.recover("conflict handler")
+			p.conflictHandler(Conflict{Selected: selected, SpanContexts: extracted})
+		}()
+	}
+	return trace.ContextWithSpanContext(ctx, extracted[selected])
+}
+
+// Fields returns the union of all the fields of the members.
+func (p *failOpen) Fields() []string {
+	unique := make(map[string]struct{})
+	for _, m := range p.members {
+		func() {
+			defer p.recover(m.name)
+			for _, f := range m.prop.Fields() {
+				unique[f] = struct{}{}
+			}
+		}()
+	}
+
+	fields := make([]string, 0, len(unique))
+	for k := range unique {
+		fields = append(fields, k)
+	}
+	return fields
+}
+
+// recover reports a panic of the propagator name, if any. It must be
+// deferred.
+func (p *failOpen) recover(name string) {
+	if r := recover(); r != nil {
+		otel.Handle(fmt.Errorf("%w: %s: %v", errPropagatorPanic, name, r))
+	}
+}
+
+// conflicting returns whether the span contexts are of different traces or
+// spans.
+func conflicting(scs map[string]trace.SpanContext) bool {
+	var first trace.SpanContext
+	for _, sc := range scs {
+		if !first.IsValid() {
+			first = sc
+			continue
+		}
+		if sc.TraceID() != first.TraceID() || sc.SpanID() != first.SpanID() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,226 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package autoprop
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	traceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	b3Header    = "80f198ee56343ba864fe8b2a57d3eff7-e457b5a2e4d86bd1-1"
+)
+
+var (
+	tcTraceID = trace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36}
+	b3TraceID = trace.TraceID{0x80, 0xf1, 0x98, 0xee, 0x56, 0x34, 0x3b, 0xa8, 0x64, 0xfe, 0x8b, 0x2a, 0x57, 0xd3, 0xef, 0xf7}
+)
+
+type panicker struct{}
+
+func (panicker) Inject(context.Context, propagation.TextMapCarrier) { panic("inject") }
+
+func (panicker) Extract(context.Context, propagation.TextMapCarrier) context.Context {
+	panic("extract")
+}
+
+func (panicker) Fields() []string { panic("fields") }
+
+// registerPanicker registers a propagator that always panics as "panic".
+func registerPanicker(t *testing.T) {
+	RegisterTextMapPropagator("panic", panicker{})
+	t.Cleanup(func() { propagators.drop("panic") })
+}
+
+type errorHandler struct {
+	errs []error
+}
+
+func (h *errorHandler) Handle(err error) { h.errs = append(h.errs, err) }
+
+// setErrorHandler sets an errorHandler as the global error handler for the
+// duration of the test.
+func setErrorHandler(t *testing.T) *errorHandler {
+	prev := otel.GetErrorHandler()
+	h := &errorHandler{}
+	otel.SetErrorHandler(h)
+	t.Cleanup(func() { otel.SetErrorHandler(prev) })
+	return h
+}
+
+func conflictingCarrier() propagation.MapCarrier {
+	return propagation.MapCarrier{
+		"traceparent": traceparent,
+		"b3":          b3Header,
+	}
+}
+
+func TestFailOpenPanics(t *testing.T) {
+	t.Setenv(otelPropagatorsEnvKey, "")
+	registerPanicker(t)
+	h := setErrorHandler(t)
+
+	p := NewFailOpenTextMapPropagator(WithPropagators("panic", "tracecontext", "panic"))
+
+	assert.ElementsMatch(t, []string{"traceparent", "tracestate"}, p.Fields())
+
+	ctx := p.Extract(context.Background(), propagation.MapCarrier{"traceparent": traceparent})
+	sc := trace.SpanContextFromContext(ctx)
+	assert.Equal(t, tcTraceID, sc.TraceID())
+
+	carrier := propagation.MapCarrier{}
+	p.Inject(ctx, carrier)
+	assert.Equal(t, traceparent, carrier.Get("traceparent"))
+
+	require.Len(t, h.errs, 6)
+	for _, err := range h.errs {
+		assert.ErrorIs(t, err, errPropagatorPanic)
+	}
+}
+
+func TestFailOpenConflictLastWins(t *testing.T) {
+	t.Setenv(otelPropagatorsEnvKey, "")
+	var got []Conflict
+	p := NewFailOpenTextMapPropagator(
+		WithPropagators("tracecontext", "b3"),
+		WithConflictHandler(func(c Conflict) { got = append(got, c) }),
+	)
+
+	ctx := p.Extract(context.Background(), conflictingCarrier())
+	assert.Equal(t, b3TraceID, trace.SpanContextFromContext(ctx).TraceID())
+
+	require.Len(t, got, 1)
+	assert.Equal(t, "b3", got[0].Selected)
+	require.Len(t, got[0].SpanContexts, 2)
+	assert.Equal(t, tcTraceID, got[0].SpanContexts["tracecontext"].TraceID())
+	assert.Equal(t, b3TraceID, got[0].SpanContexts["b3"].TraceID())
+}
+
+func TestFailOpenConflictPriority(t *testing.T) {
+	t.Setenv(otelPropagatorsEnvKey, "")
+	var got []Conflict
+	p := NewFailOpenTextMapPropagator(
+		WithPropagators("tracecontext", "b3", "baggage"),
+		WithPriority("unknown", "tracecontext"),
+		WithConflictHandler(func(c Conflict) { got = append(got, c) }),
+	)
+
+	carrier := conflictingCarrier()
+	carrier["baggage"] = "key=value"
+	ctx := p.Extract(context.Background(), carrier)
+	sc := trace.SpanContextFromContext(ctx)
+	assert.Equal(t, tcTraceID, sc.TraceID())
+	assert.True(t, sc.IsRemote())
+
+	require.Len(t, got, 1)
+	assert.Equal(t, "tracecontext", got[0].Selected)
+}
+
+func TestFailOpenNoConflict(t *testing.T) {
+	t.Setenv(otelPropagatorsEnvKey, "")
+	called := false
+	p := NewFailOpenTextMapPropagator(
+		WithPropagators("tracecontext", "b3"),
+		WithPriority("tracecontext"),
+		WithConflictHandler(func(Conflict) { called = true }),
+	)
+
+	// Both formats carry the same span, the tracestate only being in the
+	// W3C format.
+	carrier := propagation.MapCarrier{
+		"traceparent": traceparent,
+		"tracestate":  "rojo=00f067aa0ba902b7",
+		"b3":          "4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-1",
+	}
+	sc := trace.SpanContextFromContext(p.Extract(context.Background(), carrier))
+	assert.Equal(t, tcTraceID, sc.TraceID())
+	assert.Equal(t, "rojo=00f067aa0ba902b7", sc.TraceState().String())
+	assert.False(t, called)
+}
+
+func TestFailOpenConflictMetric(t *testing.T) {
+	t.Setenv(otelPropagatorsEnvKey, "")
+	reader := sdkmetric.NewManualReader()
+	p := NewFailOpenTextMapPropagator(
+		WithPropagators("tracecontext", "b3"),
+		WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))),
+	)
+
+	_ = p.Extract(context.Background(), conflictingCarrier())
+	_ = p.Extract(context.Background(), conflictingCarrier())
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	assert.Equal(t, ScopeName, rm.ScopeMetrics[0].Scope.Name)
+	require.Len(t, rm.ScopeMetrics[0].Metrics, 1)
+	metricdatatest.AssertEqual(t, metricdata.Metrics{
+		Name:        "propagator.extract.conflicts",
+		Description: "Number of carriers from which propagators extracted conflicting span contexts",
+		Unit:        "{conflict}",
+		Data: metricdata.Sum[int64]{
+			Temporality: metricdata.CumulativeTemporality,
+			IsMonotonic: true,
+			DataPoints: []metricdata.DataPoint[int64]{
+				{Attributes: attribute.NewSet(attribute.String("propagator", "b3")), Value: 2},
+			},
+		},
+	}, rm.ScopeMetrics[0].Metrics[0], metricdatatest.IgnoreTimestamp())
+}
+
+func TestFailOpenConflictHandlerPanics(t *testing.T) {
+	t.Setenv(otelPropagatorsEnvKey, "")
+	h := setErrorHandler(t)
+	p := NewFailOpenTextMapPropagator(
+		WithPropagators("tracecontext", "b3"),
+		WithConflictHandler(func(Conflict) { panic("handler") }),
+	)
+
+	ctx := p.Extract(context.Background(), conflictingCarrier())
+	assert.Equal(t, b3TraceID, trace.SpanContextFromContext(ctx).TraceID())
+	require.Len(t, h.errs, 1)
+	assert.ErrorIs(t, h.errs[0], errPropagatorPanic)
+}
+
+func TestFailOpenEnv(t *testing.T) {
+	h := setErrorHandler(t)
+	t.Setenv(otelPropagatorsEnvKey, "b3,unknown")
+
+	p := NewFailOpenTextMapPropagator(WithPropagators("tracecontext"))
+	assert.ElementsMatch(t, []string{"x-b3-traceid", "x-b3-spanid", "x-b3-sampled", "x-b3-flags"}, p.Fields())
+	require.Len(t, h.errs, 1)
+	assert.ErrorIs(t, h.errs[0], errUnknownPropagator)
+
+	t.Setenv(otelPropagatorsEnvKey, "b3,none")
+	assert.Equal(t, noop, NewFailOpenTextMapPropagator())
+}
+
+func TestFailOpenDefault(t *testing.T) {
+	t.Setenv(otelPropagatorsEnvKey, "")
+	p := NewFailOpenTextMapPropagator()
+	assert.ElementsMatch(t, []string{"traceparent", "tracestate", "baggage"}, p.Fields())
+}
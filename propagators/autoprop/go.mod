@@ -6,9 +6,13 @@ require (
 	github.com/stretchr/testify v1.8.4
 	go.opentelemetry.io/contrib/propagators/aws v1.21.1
 	go.opentelemetry.io/contrib/propagators/b3 v1.21.1
+	go.opentelemetry.io/contrib/propagators/datadog v0.46.1
 	go.opentelemetry.io/contrib/propagators/jaeger v1.21.1
 	go.opentelemetry.io/contrib/propagators/ot v1.21.1
 	go.opentelemetry.io/otel v1.21.0
+	go.opentelemetry.io/otel/metric v1.21.0
+	go.opentelemetry.io/otel/sdk/metric v1.21.0
+	go.opentelemetry.io/otel/trace v1.21.0
 )
 
 require (
@@ -16,9 +20,7 @@ require (
 	github.com/go-logr/logr v1.4.1 // indirect
 	github.com/go-logr/stdr v1.2.2 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	go.opentelemetry.io/otel/metric v1.21.0 // indirect
 	go.opentelemetry.io/otel/sdk v1.21.0 // indirect
-	go.opentelemetry.io/otel/trace v1.21.0 // indirect
 	go.uber.org/multierr v1.11.0 // indirect
 	golang.org/x/sys v0.14.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
@@ -31,3 +33,5 @@ replace go.opentelemetry.io/contrib/propagators/b3 => ../b3
 replace go.opentelemetry.io/contrib/propagators/aws => ../aws
 
 replace go.opentelemetry.io/contrib/propagators/ot => ../ot
+
+replace go.opentelemetry.io/contrib/propagators/datadog => ../datadog
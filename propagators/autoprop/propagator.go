@@ -33,9 +33,9 @@ const otelPropagatorsEnvKey = "OTEL_PROPAGATORS"
 // to the once composited by props.
 //
 // The propagators supported with the OTEL_PROPAGATORS environment variable by
-// default are: tracecontext, baggage, b3, b3multi, jaeger, xray, ottrace, and
-// none. Each of these values, and their combination, are supported in
-// conformance with the OpenTelemetry specification. See
+// default are: tracecontext, baggage, b3, b3multi, jaeger, xray, ottrace,
+// datadog, and none. Each of these values, and their combination, are
+// supported in conformance with the OpenTelemetry specification. See
 // https://github.com/open-telemetry/opentelemetry-specification/blob/main/specification/sdk-environment-variables.md#general-sdk-configuration
 // for more information.
 //
@@ -82,9 +82,19 @@ var errUnknownPropagator = errors.New("unknown propagator")
 // TextMapPropagator will be returned if "none" is defined anywhere in the
 // environment variable.
 func parseEnv() (propagation.TextMapPropagator, error) {
+	names := envNames()
+	if names == nil {
+		return nil, nil
+	}
+	return TextMapPropagator(names...)
+}
+
+// envNames returns the propagator names listed in the OTEL_PROPAGATORS
+// environment variable, or nil if it is not set.
+func envNames() []string {
 	propStrs := os.Getenv(otelPropagatorsEnvKey)
 	if propStrs == "" {
-		return nil, nil
+		return nil
 	}
-	return TextMapPropagator(strings.Split(propStrs, ",")...)
+	return strings.Split(propStrs, ",")
 }
@@ -66,3 +66,17 @@ func TestNewTextMapPropagatorMultiEnvNone(t *testing.T) {
 	t.Setenv(otelPropagatorsEnvKey, "b3,none,tracecontext")
 	assert.Equal(t, noop, NewTextMapPropagator())
 }
+
+func TestNewTextMapPropagatorEnvDatadog(t *testing.T) {
+	t.Setenv(otelPropagatorsEnvKey, "tracecontext,datadog")
+	expect := []string{
+		"traceparent",
+		"tracestate",
+		"x-datadog-trace-id",
+		"x-datadog-parent-id",
+		"x-datadog-sampling-priority",
+		"x-datadog-origin",
+		"x-datadog-tags",
+	}
+	assert.ElementsMatch(t, expect, NewTextMapPropagator().Fields())
+}
@@ -22,6 +22,7 @@ import (
 
 	"go.opentelemetry.io/contrib/propagators/aws/xray"
 	"go.opentelemetry.io/contrib/propagators/b3"
+	"go.opentelemetry.io/contrib/propagators/datadog"
 	"go.opentelemetry.io/contrib/propagators/jaeger"
 	"go.opentelemetry.io/contrib/propagators/ot"
 	"go.opentelemetry.io/otel/propagation"
@@ -49,6 +50,8 @@ var propagators = &registry{
 		"xray": xray.Propagator{},
 		// OpenTracing Trace.
 		"ottrace": ot.OT{},
+		// Datadog.
+		"datadog": datadog.Propagator{},
 
 		// No-op TextMapPropagator.
 		none: propagation.NewCompositeTextMapPropagator(),
@@ -101,7 +104,7 @@ func (r *registry) drop(key string) {
 // RegisterTextMapPropagator sets the TextMapPropagator p to be used when the
 // OTEL_PROPAGATORS environment variable contains the propagator name. This
 // will panic if name has already been registered or is a default
-// (tracecontext, baggage, b3, b3multi, jaeger, xray, or ottrace).
+// (tracecontext, baggage, b3, b3multi, jaeger, xray, ottrace, or datadog).
 func RegisterTextMapPropagator(name string, p propagation.TextMapPropagator) {
 	if err := propagators.store(name, p); err != nil {
 		// envRegistry.store will return errDupReg if name is already
@@ -121,7 +124,7 @@ func RegisterTextMapPropagator(name string, p propagation.TextMapPropagator) {
 // passed names of registered TextMapPropagators. Each name must match an
 // already registered TextMapPropagator (see the RegisterTextMapPropagator
 // function for more information) or a default (tracecontext, baggage, b3,
-// b3multi, jaeger, xray, or ottrace).
+// b3multi, jaeger, xray, ottrace, or datadog).
 //
 // If "none" is included in the arguments, or no names are provided, the
 // returned TextMapPropagator will be a no-operation implementation.
@@ -130,17 +133,43 @@ func RegisterTextMapPropagator(name string, p propagation.TextMapPropagator) {
 // names will be used to compose a TextMapPropagator that is returned with the
 // error.
 func TextMapPropagator(names ...string) (propagation.TextMapPropagator, error) {
-	var (
-		props   []propagation.TextMapPropagator
-		unknown []string
-	)
+	members, isNone, err := lookup(names)
+	if isNone {
+		// If "none" is passed in combination with any other propagator,
+		// the result still needs to be a no-op propagator.
+		return propagation.NewCompositeTextMapPropagator(), nil
+	}
+
+	switch len(members) {
+	case 0:
+		return nil, err
+	case 1:
+		// Do not return a composite of a single propagator.
+		return members[0].prop, err
+	default:
+		props := make([]propagation.TextMapPropagator, len(members))
+		for i, m := range members {
+			props[i] = m.prop
+		}
+		return propagation.NewCompositeTextMapPropagator(props...), err
+	}
+}
 
+// namedPropagator is a registered TextMapPropagator and its name.
+type namedPropagator struct {
+	name string
+	prop propagation.TextMapPropagator
+}
+
+// lookup returns the registered TextMapPropagators with the names, in order.
+// If "none" is one of the names, isNone is true and no propagator is
+// returned. Unknown names are ignored and reported in an error wrapping
+// errUnknownPropagator.
+func lookup(names []string) (props []namedPropagator, isNone bool, err error) {
+	var unknown []string
 	for _, name := range names {
 		if name == none {
-			// If "none" is passed in combination with any other propagator,
-			// the result still needs to be a no-op propagator. Therefore,
-			// short-circuit here.
-			return propagation.NewCompositeTextMapPropagator(), nil
+			return nil, true, nil
 		}
 
 		p, ok := propagators.load(name)
@@ -148,22 +177,12 @@ func TextMapPropagator(names ...string) (propagation.TextMapPropagator, error) {
 			unknown = append(unknown, name)
 			continue
 		}
-		props = append(props, p)
+		props = append(props, namedPropagator{name: name, prop: p})
 	}
 
-	var err error
 	if len(unknown) > 0 {
 		joined := strings.Join(unknown, ",")
 		err = fmt.Errorf("%w: %s", errUnknownPropagator, joined)
 	}
-
-	switch len(props) {
-	case 0:
-		return nil, err
-	case 1:
-		// Do not return a composite of a single propagator.
-		return props[0], err
-	default:
-		return propagation.NewCompositeTextMapPropagator(props...), err
-	}
+	return props, isNone, err
 }
@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xray // import "go.opentelemetry.io/contrib/propagators/aws/xray"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+type xrayKeyType int
+
+const headerKey xrayKeyType = iota
+
+// header holds the parts of an extracted X-Amzn-Trace-Id header that are not
+// represented by the span context.
+type header struct {
+	// traceID and spanID identify the span context the header was
+	// extracted into.
+	traceID trace.TraceID
+	spanID  trace.SpanID
+	// deferred is true if the header contained Sampled=?.
+	deferred bool
+	// fields are the key=value pairs other than Root, Parent and Sampled
+	// (e.g. Lineage), in the order they were received.
+	fields []string
+}
+
+// withHeader returns a copy of parent with h stored in it.
+func withHeader(parent context.Context, h *header) context.Context {
+	return context.WithValue(parent, headerKey, h)
+}
+
+// headerFromContext returns the header stored in ctx.
+//
+// If no header is stored in ctx nil is returned.
+func headerFromContext(ctx context.Context) *header {
+	if ctx == nil {
+		return nil
+	}
+	if h, ok := ctx.Value(headerKey).(*header); ok {
+		return h
+	}
+	return nil
+}
+
+// SamplingDeferred returns true if the remote span context in ctx was
+// extracted from an X-Amzn-Trace-Id header with Sampled=?, in which case
+// the sampling decision is left to the receiver. The span context is not
+// sampled, so samplers respecting the parent decision drop the trace unless
+// they check SamplingDeferred first, as DeferredSampler does.
+func SamplingDeferred(ctx context.Context) bool {
+	h := headerFromContext(ctx)
+	if h == nil || !h.deferred {
+		return false
+	}
+	sc := trace.SpanContextFromContext(ctx)
+	return sc.IsRemote() && sc.TraceID() == h.traceID && sc.SpanID() == h.spanID
+}
@@ -32,8 +32,10 @@ const (
 	parentIDKey          = "Parent"
 	traceIDVersion       = "1"
 	traceIDDelimiter     = "-"
+	selfKey              = "Self"
 	isSampled            = "1"
 	notSampled           = "0"
+	deferredSampled      = "?"
 
 	traceFlagNone           = 0x0
 	traceFlagSampled        = 0x1 << 0
@@ -58,6 +60,14 @@ var (
 // Example AWS X-Ray format:
 //
 // X-Amzn-Trace-Id: Root={traceId};Parent={parentId};Sampled={samplingFlag}.
+//
+// Other fields of an extracted header, like Lineage, are kept in the context
+// and injected again along with the span context of the same trace. A
+// deferred sampling decision (Sampled=?) is extracted as a span context that
+// is not sampled and can be checked with SamplingDeferred. Samplers following
+// the parent decision drop these traces; use DeferredSampler for the
+// decision to be made by the receiver. It is injected as is while the
+// extracted span context is still the current one.
 type Propagator struct{}
 
 // Asserts that the propagator implements the otel.TextMapPropagator interface at compile time.
@@ -76,11 +86,18 @@ func (xray Propagator) Inject(ctx context.Context, carrier propagation.TextMapCa
 	samplingFlag := notSampled
 	if sc.TraceFlags() == traceFlagSampled {
 		samplingFlag = isSampled
+	} else if SamplingDeferred(ctx) {
+		samplingFlag = deferredSampled
 	}
 	headers := []string{
 		traceIDKey, kvDelimiter, xrayTraceID, traceHeaderDelimiter, parentIDKey,
 		kvDelimiter, parentID.String(), traceHeaderDelimiter, sampleFlagKey, kvDelimiter, samplingFlag,
 	}
+	if h := headerFromContext(ctx); h != nil && h.traceID == sc.TraceID() {
+		for _, field := range h.fields {
+			headers = append(headers, traceHeaderDelimiter, field)
+		}
+	}
 
 	carrier.Set(traceHeaderKey, strings.Join(headers, ""))
 }
@@ -88,19 +105,25 @@ func (xray Propagator) Inject(ctx context.Context, carrier propagation.TextMapCa
 // Extract gets a context from the carrier if it contains AWS X-Ray headers.
 func (xray Propagator) Extract(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
 	// extract tracing information
-	if header := carrier.Get(traceHeaderKey); header != "" {
-		sc, err := extract(header)
+	if headerVal := carrier.Get(traceHeaderKey); headerVal != "" {
+		sc, h, err := extract(headerVal)
 		if err == nil && sc.IsValid() {
+			if h.deferred || len(h.fields) > 0 {
+				h.traceID, h.spanID = sc.TraceID(), sc.SpanID()
+				ctx = withHeader(ctx, h)
+			}
 			return trace.ContextWithRemoteSpanContext(ctx, sc)
 		}
 	}
 	return ctx
 }
 
-// extract extracts Span Context from context.
-func extract(headerVal string) (trace.SpanContext, error) {
+// extract extracts Span Context from context, along with the header fields
+// it does not represent.
+func extract(headerVal string) (trace.SpanContext, *header, error) {
 	var (
 		scc            = trace.SpanContextConfig{}
+		h              = &header{}
 		err            error
 		delimiterIndex int
 		part           string
@@ -118,26 +141,33 @@ func extract(headerVal string) (trace.SpanContext, error) {
 		}
 		equalsIndex := strings.Index(part, kvDelimiter)
 		if equalsIndex < 0 {
-			return empty, errInvalidTraceHeader
+			return empty, nil, errInvalidTraceHeader
 		}
 		value := part[equalsIndex+1:]
-		if strings.HasPrefix(part, traceIDKey) {
+		switch strings.TrimSpace(part[:equalsIndex]) {
+		case traceIDKey:
 			scc.TraceID, err = parseTraceID(value)
 			if err != nil {
-				return empty, err
+				return empty, nil, err
 			}
-		} else if strings.HasPrefix(part, parentIDKey) {
+		case parentIDKey:
 			// extract parentId
 			scc.SpanID, err = trace.SpanIDFromHex(value)
 			if err != nil {
-				return empty, errInvalidSpanIDLength
+				return empty, nil, errInvalidSpanIDLength
 			}
-		} else if strings.HasPrefix(part, sampleFlagKey) {
+		case sampleFlagKey:
 			// extract traceflag
+			h.deferred = value == deferredSampled
 			scc.TraceFlags = parseTraceFlag(value)
+		case selfKey:
+			// Self is added by Elastic Load Balancing for its own hop and
+			// is not forwarded.
+		default:
+			h.fields = append(h.fields, strings.TrimSpace(part))
 		}
 	}
-	return trace.NewSpanContext(scc), nil
+	return trace.NewSpanContext(scc), h, nil
 }
 
 // indexOf returns position of the first occurrence of a substr in str starting at pos index.
@@ -87,7 +87,7 @@ func TestAwsXrayExtract(t *testing.T) {
 			test.parentSpanID, traceHeaderDelimiter, sampleFlagKey, kvDelimiter, test.samplingFlag,
 		}, "")
 
-		sc, err := extract(headerVal)
+		sc, _, err := extract(headerVal)
 
 		info := []interface{}{
 			"trace ID: %q, parent span ID: %q, sampling flag: %q",
@@ -104,6 +104,70 @@ func TestAwsXrayExtract(t *testing.T) {
 	}
 }
 
+func TestAwsXrayPreserveFields(t *testing.T) {
+	propagator := Propagator{}
+	headerVal := "Self=1-67891234-12456789abcdef012345678;Root=" + xrayTraceID + ";Parent=" + parentID64Str +
+		";Sampled=1;Lineage=a87bd80c:1|68fd508a:5; CalledFrom=Foo"
+
+	carrier := propagation.MapCarrier{traceHeaderKey: headerVal}
+	ctx := propagator.Extract(context.Background(), carrier)
+	sc := trace.SpanContextFromContext(ctx)
+	assert.Equal(t, traceID, sc.TraceID())
+	assert.Equal(t, parentSpanID, sc.SpanID())
+	assert.True(t, sc.IsSampled())
+	assert.False(t, SamplingDeferred(ctx))
+
+	// Fields are injected again with a child span of the same trace.
+	childSpanID := trace.SpanID{0x1, 0x2, 0x3, 0x4, 0x5, 0x6, 0x7, 0x8}
+	child := trace.ContextWithSpanContext(ctx, sc.WithSpanID(childSpanID).WithRemote(false))
+	carrier = propagation.MapCarrier{}
+	propagator.Inject(child, carrier)
+	assert.Equal(t, "Root="+xrayTraceID+";Parent=0102030405060708;Sampled=1;Lineage=a87bd80c:1|68fd508a:5;CalledFrom=Foo", carrier.Get(traceHeaderKey))
+
+	// But not with a span of another trace.
+	other := trace.ContextWithSpanContext(ctx, trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x1},
+		SpanID:     childSpanID,
+		TraceFlags: traceFlagSampled,
+	}))
+	carrier = propagation.MapCarrier{}
+	propagator.Inject(other, carrier)
+	assert.Equal(t, "Root=1-01000000-000000000000000000000000;Parent=0102030405060708;Sampled=1", carrier.Get(traceHeaderKey))
+}
+
+func TestAwsXrayDeferredSampling(t *testing.T) {
+	propagator := Propagator{}
+	headerVal := "Root=" + xrayTraceID + ";Parent=" + parentID64Str + ";Sampled=?"
+
+	ctx := propagator.Extract(context.Background(), propagation.MapCarrier{traceHeaderKey: headerVal})
+	sc := trace.SpanContextFromContext(ctx)
+	assert.True(t, sc.IsValid())
+	assert.False(t, sc.IsSampled())
+	assert.True(t, SamplingDeferred(ctx))
+
+	// The deferred decision is forwarded with the extracted span context.
+	carrier := propagation.MapCarrier{}
+	propagator.Inject(ctx, carrier)
+	assert.Equal(t, headerVal, carrier.Get(traceHeaderKey))
+
+	// The decision of a local span replaces it.
+	childSpanID := trace.SpanID{0x1, 0x2, 0x3, 0x4, 0x5, 0x6, 0x7, 0x8}
+	for _, flags := range []trace.TraceFlags{traceFlagNone, traceFlagSampled} {
+		child := trace.ContextWithSpanContext(ctx, sc.WithSpanID(childSpanID).WithTraceFlags(flags).WithRemote(false))
+		assert.False(t, SamplingDeferred(child))
+
+		carrier = propagation.MapCarrier{}
+		propagator.Inject(child, carrier)
+		want := notSampled
+		if flags.IsSampled() {
+			want = isSampled
+		}
+		assert.Equal(t, "Root="+xrayTraceID+";Parent=0102030405060708;Sampled="+want, carrier.Get(traceHeaderKey))
+	}
+
+	assert.False(t, SamplingDeferred(context.Background()))
+}
+
 func BenchmarkPropagatorExtract(b *testing.B) {
 	propagator := Propagator{}
 
@@ -0,0 +1,55 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xray // import "go.opentelemetry.io/contrib/propagators/aws/xray"
+
+import (
+	"fmt"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// DeferredSampler returns a Sampler following the sampling decision of the
+// parent span, as the Sampler returned by sdktrace.ParentBased with root and
+// options does, except for the spans whose remote parent was extracted from
+// an X-Amzn-Trace-Id header with Sampled=?. The sampling decision of these
+// spans is left to the receiver and is made by root, as for root spans.
+func DeferredSampler(root sdktrace.Sampler, options ...sdktrace.ParentBasedSamplerOption) sdktrace.Sampler {
+	return deferredSampler{
+		root:        root,
+		parentBased: sdktrace.ParentBased(root, options...),
+	}
+}
+
+type deferredSampler struct {
+	root        sdktrace.Sampler
+	parentBased sdktrace.Sampler
+}
+
+var _ sdktrace.Sampler = deferredSampler{}
+
+// ShouldSample delegates the sampling decision of the spans whose sampling
+// was deferred to the root Sampler, and of the other spans to the parent
+// based Sampler.
+func (s deferredSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	if SamplingDeferred(p.ParentContext) {
+		return s.root.ShouldSample(p)
+	}
+	return s.parentBased.ShouldSample(p)
+}
+
+// Description returns the description of the Sampler.
+func (s deferredSampler) Description() string {
+	return fmt.Sprintf("XRayDeferredSampler{%s}", s.parentBased.Description())
+}
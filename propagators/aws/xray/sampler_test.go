@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xray
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func newDeferredTracerProvider(t *testing.T, root sdktrace.Sampler) (*sdktrace.TracerProvider, *tracetest.SpanRecorder) {
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSampler(DeferredSampler(root)),
+		sdktrace.WithSpanProcessor(sr),
+	)
+	t.Cleanup(func() { _ = tp.Shutdown(context.Background()) })
+	return tp, sr
+}
+
+func extractHeader(sampled string) context.Context {
+	return Propagator{}.Extract(context.Background(), propagation.MapCarrier{
+		traceHeaderKey: "Root=" + xrayTraceID + ";Parent=" + parentID64Str + ";Sampled=" + sampled + ";Lineage=a87bd80c:1",
+	})
+}
+
+func TestDeferredSamplerDeferred(t *testing.T) {
+	tp, sr := newDeferredTracerProvider(t, sdktrace.AlwaysSample())
+	tracer := tp.Tracer("test")
+
+	ctx, server := tracer.Start(extractHeader(deferredSampled), "server")
+	_, client := tracer.Start(ctx, "client")
+	client.End()
+	server.End()
+
+	assert.True(t, server.SpanContext().IsSampled())
+	assert.Equal(t, traceID, server.SpanContext().TraceID())
+	assert.True(t, client.SpanContext().IsSampled())
+	require.Len(t, sr.Ended(), 2)
+
+	// The decision made by the receiver is propagated downstream.
+	carrier := propagation.MapCarrier{}
+	Propagator{}.Inject(ctx, carrier)
+	assert.Equal(t,
+		"Root="+xrayTraceID+";Parent="+server.SpanContext().SpanID().String()+";Sampled=1;Lineage=a87bd80c:1",
+		carrier.Get(traceHeaderKey))
+}
+
+func TestDeferredSamplerDeferredNotSampled(t *testing.T) {
+	tp, sr := newDeferredTracerProvider(t, sdktrace.NeverSample())
+
+	_, span := tp.Tracer("test").Start(extractHeader(deferredSampled), "server")
+	span.End()
+
+	assert.False(t, span.SpanContext().IsSampled())
+	assert.Empty(t, sr.Ended())
+}
+
+func TestDeferredSamplerParentBased(t *testing.T) {
+	tp, sr := newDeferredTracerProvider(t, sdktrace.AlwaysSample())
+	tracer := tp.Tracer("test")
+
+	_, notSampledSpan := tracer.Start(extractHeader(notSampled), "not sampled")
+	notSampledSpan.End()
+	assert.False(t, notSampledSpan.SpanContext().IsSampled())
+
+	_, sampledSpan := tracer.Start(extractHeader(isSampled), "sampled")
+	sampledSpan.End()
+	assert.True(t, sampledSpan.SpanContext().IsSampled())
+
+	_, root := tracer.Start(context.Background(), "root")
+	root.End()
+	assert.True(t, root.SpanContext().IsSampled())
+
+	require.Len(t, sr.Ended(), 2)
+}
+
+func TestDeferredSamplerDescription(t *testing.T) {
+	assert.Equal(t,
+		"XRayDeferredSampler{ParentBased{root:AlwaysOnSampler,remoteParentSampled:AlwaysOnSampler,remoteParentNotSampled:AlwaysOffSampler,localParentSampled:AlwaysOnSampler,localParentNotSampled:AlwaysOffSampler}}",
+		DeferredSampler(sdktrace.AlwaysSample()).Description())
+}
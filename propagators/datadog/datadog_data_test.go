@@ -0,0 +1,367 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog_test
+
+import (
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	traceIDHeader          = "x-datadog-trace-id"
+	parentIDHeader         = "x-datadog-parent-id"
+	samplingPriorityHeader = "x-datadog-sampling-priority"
+	originHeader           = "x-datadog-origin"
+	tagsHeader             = "x-datadog-tags"
+)
+
+const (
+	// traceIDLowStr and spanIDStr are the decimal representations of the
+	// lower 64 bits of traceID and of spanID.
+	traceIDLowStr = "11803532876627986230"
+	traceIDHigh   = "4bf92f3577b34da6"
+	spanIDStr     = "67667974448284343"
+)
+
+var (
+	traceID   = trace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36}
+	traceID64 = trace.TraceID{0, 0, 0, 0, 0, 0, 0, 0, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36}
+	spanID    = trace.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7}
+)
+
+func mustTraceState(s string) trace.TraceState {
+	ts, err := trace.ParseTraceState(s)
+	if err != nil {
+		panic(err)
+	}
+	return ts
+}
+
+type extractTest struct {
+	name    string
+	headers map[string]string
+	wantScc trace.SpanContextConfig
+}
+
+var extractHeaders = []extractTest{
+	{
+		name:    "empty",
+		headers: map[string]string{},
+		wantScc: trace.SpanContextConfig{},
+	},
+	{
+		name: "no sampling priority",
+		headers: map[string]string{
+			traceIDHeader:  traceIDLowStr,
+			parentIDHeader: spanIDStr,
+		},
+		wantScc: trace.SpanContextConfig{
+			TraceID: traceID64,
+			SpanID:  spanID,
+		},
+	},
+	{
+		name: "auto keep",
+		headers: map[string]string{
+			traceIDHeader:          traceIDLowStr,
+			parentIDHeader:         spanIDStr,
+			samplingPriorityHeader: "1",
+		},
+		wantScc: trace.SpanContextConfig{
+			TraceID:    traceID64,
+			SpanID:     spanID,
+			TraceFlags: trace.FlagsSampled,
+			TraceState: mustTraceState("dd=s:1"),
+		},
+	},
+	{
+		name: "user keep",
+		headers: map[string]string{
+			traceIDHeader:          traceIDLowStr,
+			parentIDHeader:         spanIDStr,
+			samplingPriorityHeader: "2",
+		},
+		wantScc: trace.SpanContextConfig{
+			TraceID:    traceID64,
+			SpanID:     spanID,
+			TraceFlags: trace.FlagsSampled,
+			TraceState: mustTraceState("dd=s:2"),
+		},
+	},
+	{
+		name: "auto reject",
+		headers: map[string]string{
+			traceIDHeader:          traceIDLowStr,
+			parentIDHeader:         spanIDStr,
+			samplingPriorityHeader: "0",
+		},
+		wantScc: trace.SpanContextConfig{
+			TraceID:    traceID64,
+			SpanID:     spanID,
+			TraceState: mustTraceState("dd=s:0"),
+		},
+	},
+	{
+		name: "user reject",
+		headers: map[string]string{
+			traceIDHeader:          traceIDLowStr,
+			parentIDHeader:         spanIDStr,
+			samplingPriorityHeader: "-1",
+		},
+		wantScc: trace.SpanContextConfig{
+			TraceID:    traceID64,
+			SpanID:     spanID,
+			TraceState: mustTraceState("dd=s:-1"),
+		},
+	},
+	{
+		name: "128-bit trace ID",
+		headers: map[string]string{
+			traceIDHeader:          traceIDLowStr,
+			parentIDHeader:         spanIDStr,
+			samplingPriorityHeader: "1",
+			tagsHeader:             "_dd.p.tid=" + traceIDHigh,
+		},
+		wantScc: trace.SpanContextConfig{
+			TraceID:    traceID,
+			SpanID:     spanID,
+			TraceFlags: trace.FlagsSampled,
+			TraceState: mustTraceState("dd=s:1"),
+		},
+	},
+	{
+		name: "origin and tags",
+		headers: map[string]string{
+			traceIDHeader:          traceIDLowStr,
+			parentIDHeader:         spanIDStr,
+			samplingPriorityHeader: "2",
+			originHeader:           "synthetics",
+			tagsHeader:             "_dd.p.dm=-4,_dd.p.tid=" + traceIDHigh + ",_dd.p.usr=a=b,other=ignored",
+		},
+		wantScc: trace.SpanContextConfig{
+			TraceID:    traceID,
+			SpanID:     spanID,
+			TraceFlags: trace.FlagsSampled,
+			TraceState: mustTraceState("dd=s:2;o:synthetics;t.dm:-4;t.usr:a~b"),
+		},
+	},
+	{
+		name: "malformed tags",
+		headers: map[string]string{
+			traceIDHeader:          traceIDLowStr,
+			parentIDHeader:         spanIDStr,
+			samplingPriorityHeader: "1",
+			tagsHeader:             "_dd.p.tid=" + traceIDHigh + ",_dd.p.dm",
+		},
+		wantScc: trace.SpanContextConfig{
+			TraceID:    traceID64,
+			SpanID:     spanID,
+			TraceFlags: trace.FlagsSampled,
+			TraceState: mustTraceState("dd=s:1"),
+		},
+	},
+	{
+		name: "invalid upper trace ID bits",
+		headers: map[string]string{
+			traceIDHeader:          traceIDLowStr,
+			parentIDHeader:         spanIDStr,
+			samplingPriorityHeader: "1",
+			tagsHeader:             "_dd.p.tid=4BF92F3577B34DA6,_dd.p.dm=-4",
+		},
+		wantScc: trace.SpanContextConfig{
+			TraceID:    traceID64,
+			SpanID:     spanID,
+			TraceFlags: trace.FlagsSampled,
+			TraceState: mustTraceState("dd=s:1;t.dm:-4"),
+		},
+	},
+}
+
+var extractInvalidHeaders = []extractTest{
+	{
+		name: "missing parent ID",
+		headers: map[string]string{
+			traceIDHeader:          traceIDLowStr,
+			samplingPriorityHeader: "1",
+		},
+	},
+	{
+		name: "missing trace ID",
+		headers: map[string]string{
+			parentIDHeader:         spanIDStr,
+			samplingPriorityHeader: "1",
+		},
+	},
+	{
+		name: "zero trace ID",
+		headers: map[string]string{
+			traceIDHeader:  "0",
+			parentIDHeader: spanIDStr,
+		},
+	},
+	{
+		name: "zero parent ID",
+		headers: map[string]string{
+			traceIDHeader:  traceIDLowStr,
+			parentIDHeader: "0",
+		},
+	},
+	{
+		name: "hex trace ID",
+		headers: map[string]string{
+			traceIDHeader:  "a3ce929d0e0e4736",
+			parentIDHeader: spanIDStr,
+		},
+	},
+	{
+		name: "trace ID overflow",
+		headers: map[string]string{
+			traceIDHeader:  "18446744073709551616",
+			parentIDHeader: spanIDStr,
+		},
+	},
+	{
+		name: "negative parent ID",
+		headers: map[string]string{
+			traceIDHeader:  traceIDLowStr,
+			parentIDHeader: "-1",
+		},
+	},
+	{
+		name: "invalid sampling priority",
+		headers: map[string]string{
+			traceIDHeader:          traceIDLowStr,
+			parentIDHeader:         spanIDStr,
+			samplingPriorityHeader: "keep",
+		},
+	},
+}
+
+type injectTest struct {
+	name        string
+	scc         trace.SpanContextConfig
+	wantHeaders map[string]string
+}
+
+var injectHeaders = []injectTest{
+	{
+		name: "sampled",
+		scc: trace.SpanContextConfig{
+			TraceID:    traceID64,
+			SpanID:     spanID,
+			TraceFlags: trace.FlagsSampled,
+		},
+		wantHeaders: map[string]string{
+			traceIDHeader:          traceIDLowStr,
+			parentIDHeader:         spanIDStr,
+			samplingPriorityHeader: "1",
+			originHeader:           "",
+			tagsHeader:             "",
+		},
+	},
+	{
+		name: "not sampled",
+		scc: trace.SpanContextConfig{
+			TraceID: traceID64,
+			SpanID:  spanID,
+		},
+		wantHeaders: map[string]string{
+			traceIDHeader:          traceIDLowStr,
+			parentIDHeader:         spanIDStr,
+			samplingPriorityHeader: "0",
+		},
+	},
+	{
+		name: "128-bit trace ID",
+		scc: trace.SpanContextConfig{
+			TraceID:    traceID,
+			SpanID:     spanID,
+			TraceFlags: trace.FlagsSampled,
+		},
+		wantHeaders: map[string]string{
+			traceIDHeader:          traceIDLowStr,
+			parentIDHeader:         spanIDStr,
+			samplingPriorityHeader: "1",
+			tagsHeader:             "_dd.p.tid=" + traceIDHigh,
+		},
+	},
+	{
+		name: "tracestate",
+		scc: trace.SpanContextConfig{
+			TraceID:    traceID,
+			SpanID:     spanID,
+			TraceFlags: trace.FlagsSampled,
+			TraceState: mustTraceState("dd=s:2;o:synthetics;p:00f067aa0ba902b7;t.tid:ffffffffffffffff;t.dm:-4;t.usr:a~b,rojo=00f067aa0ba902b7"),
+		},
+		wantHeaders: map[string]string{
+			traceIDHeader:          traceIDLowStr,
+			parentIDHeader:         spanIDStr,
+			samplingPriorityHeader: "2",
+			originHeader:           "synthetics",
+			tagsHeader:             "_dd.p.tid=" + traceIDHigh + ",_dd.p.dm=-4,_dd.p.usr=a=b",
+		},
+	},
+	{
+		name: "tracestate priority disagreeing with sampled flag",
+		scc: trace.SpanContextConfig{
+			TraceID:    traceID64,
+			SpanID:     spanID,
+			TraceState: mustTraceState("dd=s:2"),
+		},
+		wantHeaders: map[string]string{
+			traceIDHeader:          traceIDLowStr,
+			parentIDHeader:         spanIDStr,
+			samplingPriorityHeader: "0",
+		},
+	},
+	{
+		name: "tracestate user reject",
+		scc: trace.SpanContextConfig{
+			TraceID:    traceID64,
+			SpanID:     spanID,
+			TraceState: mustTraceState("dd=s:-1"),
+		},
+		wantHeaders: map[string]string{
+			traceIDHeader:          traceIDLowStr,
+			parentIDHeader:         spanIDStr,
+			samplingPriorityHeader: "-1",
+		},
+	},
+}
+
+var injectInvalidHeaders = []injectTest{
+	{
+		name: "empty",
+		scc:  trace.SpanContextConfig{},
+		wantHeaders: map[string]string{
+			traceIDHeader:          "",
+			parentIDHeader:         "",
+			samplingPriorityHeader: "",
+			originHeader:           "",
+			tagsHeader:             "",
+		},
+	},
+	{
+		name: "missing span ID",
+		scc: trace.SpanContextConfig{
+			TraceID:    traceID,
+			TraceFlags: trace.FlagsSampled,
+		},
+		wantHeaders: map[string]string{
+			traceIDHeader:          "",
+			parentIDHeader:         "",
+			samplingPriorityHeader: "",
+		},
+	},
+}
@@ -0,0 +1,187 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/contrib/propagators/datadog"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestExtractDatadog(t *testing.T) {
+	testGroup := []struct {
+		name  string
+		tests []extractTest
+	}{
+		{
+			name:  "valid extract headers",
+			tests: extractHeaders,
+		},
+		{
+			name:  "invalid extract headers",
+			tests: extractInvalidHeaders,
+		},
+	}
+
+	for _, tg := range testGroup {
+		propagator := datadog.Propagator{}
+
+		for _, tt := range tg.tests {
+			t.Run(tt.name, func(t *testing.T) {
+				header := make(http.Header, len(tt.headers))
+				for h, v := range tt.headers {
+					header.Set(h, v)
+				}
+
+				ctx := context.Background()
+				ctx = propagator.Extract(ctx, propagation.HeaderCarrier(header))
+				gotSc := trace.SpanContextFromContext(ctx)
+
+				comparer := cmp.Comparer(func(a, b trace.SpanContext) bool {
+					// Do not compare remote field, it is unset on empty
+					// SpanContext.
+					newA := a.WithRemote(b.IsRemote())
+					return newA.Equal(b)
+				})
+				if diff := cmp.Diff(gotSc, trace.NewSpanContext(tt.wantScc), comparer); diff != "" {
+					t.Errorf("%s: %s: -got +want %s", tg.name, tt.name, diff)
+				}
+			})
+		}
+	}
+}
+
+func TestInjectDatadog(t *testing.T) {
+	testGroup := []struct {
+		name  string
+		tests []injectTest
+	}{
+		{
+			name:  "valid inject headers",
+			tests: injectHeaders,
+		},
+		{
+			name:  "invalid inject headers",
+			tests: injectInvalidHeaders,
+		},
+	}
+
+	for _, tg := range testGroup {
+		propagator := datadog.Propagator{}
+
+		for _, tt := range tg.tests {
+			t.Run(tt.name, func(t *testing.T) {
+				header := http.Header{}
+				ctx := trace.ContextWithSpanContext(
+					context.Background(),
+					trace.NewSpanContext(tt.scc),
+				)
+				propagator.Inject(ctx, propagation.HeaderCarrier(header))
+
+				for h, v := range tt.wantHeaders {
+					got, want := header.Get(h), v
+					if diff := cmp.Diff(got, want); diff != "" {
+						t.Errorf("%s: %s, header=%s: -got +want %s", tg.name, tt.name, h, diff)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestDatadogRoundTrip(t *testing.T) {
+	propagator := datadog.Propagator{}
+
+	header := http.Header{}
+	header.Set(traceIDHeader, traceIDLowStr)
+	header.Set(parentIDHeader, spanIDStr)
+	header.Set(samplingPriorityHeader, "2")
+	header.Set(originHeader, "rum")
+	header.Set(tagsHeader, "_dd.p.dm=-4,_dd.p.tid="+traceIDHigh)
+
+	ctx := propagator.Extract(context.Background(), propagation.HeaderCarrier(header))
+	sc := trace.SpanContextFromContext(ctx)
+	priority, ok := datadog.SamplingPriority(sc)
+	assert.True(t, ok)
+	assert.Equal(t, datadog.PriorityUserKeep, priority)
+
+	// The Datadog state survives W3C Trace Context propagation.
+	w3c := http.Header{}
+	propagation.TraceContext{}.Inject(ctx, propagation.HeaderCarrier(w3c))
+	ctx = propagation.TraceContext{}.Extract(context.Background(), propagation.HeaderCarrier(w3c))
+
+	got := http.Header{}
+	propagator.Inject(ctx, propagation.HeaderCarrier(got))
+	assert.Equal(t, traceIDLowStr, got.Get(traceIDHeader))
+	assert.Equal(t, spanIDStr, got.Get(parentIDHeader))
+	assert.Equal(t, "2", got.Get(samplingPriorityHeader))
+	assert.Equal(t, "rum", got.Get(originHeader))
+	assert.Equal(t, "_dd.p.tid="+traceIDHigh+",_dd.p.dm=-4", got.Get(tagsHeader))
+}
+
+func TestSamplingPriority(t *testing.T) {
+	_, ok := datadog.SamplingPriority(trace.SpanContext{})
+	assert.False(t, ok)
+
+	sc := trace.NewSpanContext(trace.SpanContextConfig{TraceState: mustTraceState("dd=s:-1;o:synthetics")})
+	priority, ok := datadog.SamplingPriority(sc)
+	assert.True(t, ok)
+	assert.Equal(t, datadog.PriorityUserReject, priority)
+}
+
+func FuzzExtract(f *testing.F) {
+	for _, tests := range [][]extractTest{extractHeaders, extractInvalidHeaders} {
+		for _, tt := range tests {
+			f.Add(
+				tt.headers[traceIDHeader],
+				tt.headers[parentIDHeader],
+				tt.headers[samplingPriorityHeader],
+				tt.headers[originHeader],
+				tt.headers[tagsHeader],
+			)
+		}
+	}
+
+	propagator := datadog.Propagator{}
+	f.Fuzz(func(t *testing.T, traceID, parentID, priority, origin, tags string) {
+		carrier := propagation.MapCarrier{
+			traceIDHeader:          traceID,
+			parentIDHeader:         parentID,
+			samplingPriorityHeader: priority,
+			originHeader:           origin,
+			tagsHeader:             tags,
+		}
+		sc := trace.SpanContextFromContext(propagator.Extract(context.Background(), carrier))
+		if !sc.IsValid() {
+			return
+		}
+
+		// A valid extracted span context is injected back with the same
+		// identity and sampling decision.
+		got := propagation.MapCarrier{}
+		propagator.Inject(trace.ContextWithRemoteSpanContext(context.Background(), sc), got)
+		roundTrip := trace.SpanContextFromContext(propagator.Extract(context.Background(), got))
+		if roundTrip.TraceID() != sc.TraceID() || roundTrip.SpanID() != sc.SpanID() || roundTrip.IsSampled() != sc.IsSampled() {
+			t.Errorf("round trip of %v: got %v", carrier, got)
+		}
+	})
+}
@@ -0,0 +1,22 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package datadog implements the Datadog propagation format of the
+// x-datadog-* headers used by the Datadog tracing libraries.
+//
+// The Datadog sampling priority, origin and propagated tags are kept in the
+// dd member of the W3C tracestate of the span context, as the Datadog
+// tracing libraries do, so they are preserved when the trace context is
+// propagated with the W3C Trace Context format.
+package datadog // import "go.opentelemetry.io/contrib/propagators/datadog"
@@ -0,0 +1,19 @@
+module go.opentelemetry.io/contrib/propagators/datadog
+
+go 1.20
+
+require (
+	github.com/google/go-cmp v0.6.0
+	github.com/stretchr/testify v1.8.4
+	go.opentelemetry.io/otel v1.21.0
+	go.opentelemetry.io/otel/trace v1.21.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/go-logr/logr v1.3.0 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	go.opentelemetry.io/otel/metric v1.21.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
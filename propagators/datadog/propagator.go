@@ -0,0 +1,222 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog // import "go.opentelemetry.io/contrib/propagators/datadog"
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// Default Datadog Header names.
+	traceIDHeader          = "x-datadog-trace-id"
+	parentIDHeader         = "x-datadog-parent-id"
+	samplingPriorityHeader = "x-datadog-sampling-priority"
+	originHeader           = "x-datadog-origin"
+	tagsHeader             = "x-datadog-tags"
+
+	// tagPrefix is the prefix of the keys of the propagated tags.
+	tagPrefix = "_dd.p."
+	// traceIDHighTag is the tag holding the upper 64 bits of 128-bit trace
+	// IDs as 16 lowercase hex characters.
+	traceIDHighTag = tagPrefix + "tid"
+	// maxTagsLength is the maximum length of the tags header. Longer tags
+	// are dropped, as the Datadog tracing libraries do.
+	maxTagsLength = 512
+)
+
+// Sampling priorities of the Datadog tracing libraries. A trace is sampled
+// if its sampling priority is positive.
+const (
+	// PriorityUserReject is set when the user asked to drop the trace.
+	PriorityUserReject = -1
+	// PriorityAutoReject is set when the sampler dropped the trace.
+	PriorityAutoReject = 0
+	// PriorityAutoKeep is set when the sampler kept the trace.
+	PriorityAutoKeep = 1
+	// PriorityUserKeep is set when the user asked to keep the trace.
+	PriorityUserKeep = 2
+)
+
+var (
+	empty = trace.SpanContext{}
+
+	errInvalidTraceIDHeader          = errors.New("invalid Datadog trace ID header found")
+	errInvalidParentIDHeader         = errors.New("invalid Datadog parent ID header found")
+	errInvalidSamplingPriorityHeader = errors.New("invalid Datadog sampling priority header found")
+	errInvalidTagsHeader             = errors.New("invalid Datadog tags header found")
+)
+
+// Propagator serializes SpanContext to/from Datadog headers.
+//
+// Datadog format:
+//
+//	x-datadog-trace-id: {lower 64 bits of the trace ID, decimal}
+//	x-datadog-parent-id: {span ID, decimal}
+//	x-datadog-sampling-priority: {sampling priority}
+//	x-datadog-origin: {origin}
+//	x-datadog-tags: _dd.p.tid={upper 64 bits of the trace ID, hex},_dd.p.{key}={value}
+//
+// The sampling priority, origin and tags other than _dd.p.tid are extracted
+// into the dd member of the tracestate of the span context, and injected from
+// it. The injected sampling priority always agrees with the sampled flag of
+// the span context.
+type Propagator struct{}
+
+var _ propagation.TextMapPropagator = Propagator{}
+
+// Inject injects a context into the carrier as Datadog headers.
+func (p Propagator) Inject(ctx context.Context, carrier propagation.TextMapCarrier) {
+	sc := trace.SpanFromContext(ctx).SpanContext()
+	if !sc.TraceID().IsValid() || !sc.SpanID().IsValid() {
+		return
+	}
+	traceID, spanID := sc.TraceID(), sc.SpanID()
+	s := parseState(sc.TraceState().Get(traceStateKey))
+
+	carrier.Set(traceIDHeader, strconv.FormatUint(binary.BigEndian.Uint64(traceID[8:]), 10))
+	carrier.Set(parentIDHeader, strconv.FormatUint(binary.BigEndian.Uint64(spanID[:]), 10))
+	carrier.Set(samplingPriorityHeader, strconv.Itoa(s.samplingPriority(sc.IsSampled())))
+	if s.origin != "" {
+		carrier.Set(originHeader, s.origin)
+	}
+
+	var tags []string
+	if high := binary.BigEndian.Uint64(traceID[:8]); high != 0 {
+		tags = append(tags, fmt.Sprintf("%s=%016x", traceIDHighTag, high))
+	}
+	for _, t := range s.tags {
+		tags = append(tags, t.key+"="+t.value)
+	}
+	if h := strings.Join(tags, ","); h != "" && len(h) <= maxTagsLength {
+		carrier.Set(tagsHeader, h)
+	}
+}
+
+// Extract extracts a context from the carrier if it contains Datadog headers.
+func (p Propagator) Extract(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	var (
+		traceID  = carrier.Get(traceIDHeader)
+		parentID = carrier.Get(parentIDHeader)
+		priority = carrier.Get(samplingPriorityHeader)
+		origin   = carrier.Get(originHeader)
+		tags     = carrier.Get(tagsHeader)
+	)
+	sc, err := extract(traceID, parentID, priority, origin, tags)
+	if err != nil || !sc.IsValid() {
+		return ctx
+	}
+	return trace.ContextWithRemoteSpanContext(ctx, sc)
+}
+
+// Fields returns the Datadog header keys whose values are set with Inject.
+func (p Propagator) Fields() []string {
+	return []string{traceIDHeader, parentIDHeader, samplingPriorityHeader, originHeader, tagsHeader}
+}
+
+// extract reconstructs a SpanContext from header values based on Datadog
+// headers.
+func extract(traceID, parentID, priority, origin, tags string) (trace.SpanContext, error) {
+	if traceID == "" && parentID == "" {
+		return empty, nil
+	}
+
+	var scc trace.SpanContextConfig
+
+	low, err := strconv.ParseUint(traceID, 10, 64)
+	if err != nil || low == 0 {
+		return empty, errInvalidTraceIDHeader
+	}
+	binary.BigEndian.PutUint64(scc.TraceID[8:], low)
+
+	id, err := strconv.ParseUint(parentID, 10, 64)
+	if err != nil || id == 0 {
+		return empty, errInvalidParentIDHeader
+	}
+	binary.BigEndian.PutUint64(scc.SpanID[:], id)
+
+	s := state{origin: origin}
+	if priority != "" {
+		s.priority, err = strconv.Atoi(priority)
+		if err != nil {
+			return empty, errInvalidSamplingPriorityHeader
+		}
+		s.hasPriority = true
+		if s.priority > 0 {
+			scc.TraceFlags = trace.FlagsSampled
+		}
+	}
+
+	// Malformed tags are dropped, the rest of the context is still valid.
+	parsed, _ := parseTags(tags)
+	for _, t := range parsed {
+		if t.key != traceIDHighTag {
+			s.tags = append(s.tags, t)
+			continue
+		}
+		if high, ok := parseTraceIDHigh(t.value); ok {
+			binary.BigEndian.PutUint64(scc.TraceID[:8], high)
+		}
+	}
+
+	scc.TraceState = s.traceState()
+	return trace.NewSpanContext(scc), nil
+}
+
+// tag is a propagated Datadog tag.
+type tag struct {
+	key, value string
+}
+
+// parseTags parses the value of the tags header. Only the tags with the
+// _dd.p. prefix are returned.
+func parseTags(h string) ([]tag, error) {
+	if h == "" {
+		return nil, nil
+	}
+	if len(h) > maxTagsLength {
+		return nil, errInvalidTagsHeader
+	}
+	var tags []tag
+	for _, part := range strings.Split(h, ",") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok || key == "" || value == "" || strings.ContainsAny(key, " ") {
+			return nil, errInvalidTagsHeader
+		}
+		if strings.HasPrefix(key, tagPrefix) {
+			tags = append(tags, tag{key: key, value: value})
+		}
+	}
+	return tags, nil
+}
+
+// parseTraceIDHigh parses the value of the _dd.p.tid tag.
+func parseTraceIDHigh(v string) (uint64, bool) {
+	if len(v) != 16 || strings.ToLower(v) != v {
+		return 0, false
+	}
+	high, err := strconv.ParseUint(v, 16, 64)
+	if err != nil || high == 0 {
+		return 0, false
+	}
+	return high, true
+}
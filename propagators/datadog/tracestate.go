@@ -0,0 +1,161 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog // import "go.opentelemetry.io/contrib/propagators/datadog"
+
+import (
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// traceStateKey is the key of the Datadog member of the tracestate.
+	traceStateKey = "dd"
+
+	// Keys of the fields of the Datadog tracestate member.
+	priorityField  = "s"
+	originField    = "o"
+	tagFieldPrefix = "t."
+
+	fieldDelimiter = ";"
+	kvDelimiter    = ":"
+)
+
+// state is the Datadog state that is not represented by the trace ID, span
+// ID and trace flags of a span context.
+type state struct {
+	priority    int
+	hasPriority bool
+	origin      string
+	tags        []tag
+}
+
+// SamplingPriority returns the Datadog sampling priority of sc and whether it
+// has one. It is set by the Propagator, or by the Datadog tracing libraries
+// in the tracestate they propagate.
+func SamplingPriority(sc trace.SpanContext) (int, bool) {
+	s := parseState(sc.TraceState().Get(traceStateKey))
+	return s.priority, s.hasPriority
+}
+
+// samplingPriority returns the sampling priority of s if it agrees with the
+// sampled decision, or the automatic priority for the sampled decision.
+func (s state) samplingPriority(sampled bool) int {
+	if s.hasPriority && (s.priority > 0) == sampled {
+		return s.priority
+	}
+	if sampled {
+		return PriorityAutoKeep
+	}
+	return PriorityAutoReject
+}
+
+// traceState returns a tracestate holding s in its Datadog member, formatted
+// as the Datadog tracing libraries do:
+//
+//	dd=s:{priority};o:{origin};t.{key}:{value}
+//
+// Tags are dropped if the member would not be valid with them.
+func (s state) traceState() trace.TraceState {
+	var fields []string
+	if s.hasPriority {
+		fields = append(fields, priorityField+kvDelimiter+strconv.Itoa(s.priority))
+	}
+	if s.origin != "" {
+		fields = append(fields, originField+kvDelimiter+encodeValue(s.origin))
+	}
+	if len(fields) == 0 && len(s.tags) == 0 {
+		return trace.TraceState{}
+	}
+
+	withTags := fields
+	for _, t := range s.tags {
+		key := tagFieldPrefix + encodeKey(strings.TrimPrefix(t.key, tagPrefix))
+		withTags = append(withTags, key+kvDelimiter+encodeValue(t.value))
+	}
+	ts, err := trace.TraceState{}.Insert(traceStateKey, strings.Join(withTags, fieldDelimiter))
+	if err == nil {
+		return ts
+	}
+	if len(fields) == 0 {
+		return trace.TraceState{}
+	}
+	ts, _ = trace.TraceState{}.Insert(traceStateKey, strings.Join(fields, fieldDelimiter))
+	return ts
+}
+
+// parseState parses the value of the Datadog tracestate member. Unknown and
+// malformed fields are ignored.
+func parseState(v string) state {
+	var s state
+	if v == "" {
+		return s
+	}
+	for _, field := range strings.Split(v, fieldDelimiter) {
+		key, value, ok := strings.Cut(field, kvDelimiter)
+		if !ok || value == "" {
+			continue
+		}
+		switch {
+		case key == priorityField:
+			if p, err := strconv.Atoi(value); err == nil {
+				s.priority, s.hasPriority = p, true
+			}
+		case key == originField:
+			s.origin = decodeValue(value)
+		case strings.HasPrefix(key, tagFieldPrefix) && len(key) > len(tagFieldPrefix):
+			if key == tagFieldPrefix+strings.TrimPrefix(traceIDHighTag, tagPrefix) {
+				// The upper bits of the trace ID are in the trace ID itself.
+				continue
+			}
+			s.tags = append(s.tags, tag{
+				key:   tagPrefix + strings.TrimPrefix(key, tagFieldPrefix),
+				value: decodeValue(value),
+			})
+		}
+	}
+	return s
+}
+
+// encodeKey replaces the characters of a tag key that are not allowed in
+// the Datadog tracestate member with "_".
+func encodeKey(k string) string {
+	return strings.Map(func(r rune) rune {
+		if r <= ' ' || r > '~' || strings.ContainsRune(",;:=~", r) {
+			return '_'
+		}
+		return r
+	}, k)
+}
+
+// encodeValue encodes a value of the Datadog tracestate member: "=" is
+// replaced with "~", and characters that are not allowed with "_".
+func encodeValue(v string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r == '=':
+			return '~'
+		case r < ' ' || r > '~' || strings.ContainsRune(",;~", r):
+			return '_'
+		}
+		return r
+	}, v)
+}
+
+// decodeValue decodes a value encoded with encodeValue.
+func decodeValue(v string) string {
+	return strings.ReplaceAll(v, "~", "=")
+}
@@ -0,0 +1,58 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTraceStateEncoding(t *testing.T) {
+	s := state{
+		priority:    PriorityAutoKeep,
+		hasPriority: true,
+		origin:      "syn;the,tics",
+		tags:        []tag{{key: "_dd.p.k:e=y", value: "a=b~c\n"}},
+	}
+	v := s.traceState().Get(traceStateKey)
+	assert.Equal(t, "s:1;o:syn_the_tics;t.k_e_y:a~b_c_", v)
+
+	got := parseState(v)
+	assert.Equal(t, state{
+		priority:    PriorityAutoKeep,
+		hasPriority: true,
+		origin:      "syn_the_tics",
+		tags:        []tag{{key: "_dd.p.k_e_y", value: "a=b_c_"}},
+	}, got)
+}
+
+func TestTraceStateDropsTags(t *testing.T) {
+	s := state{
+		priority:    PriorityUserKeep,
+		hasPriority: true,
+		tags:        []tag{{key: "_dd.p.long", value: strings.Repeat("x", 256)}},
+	}
+	assert.Equal(t, "s:2", s.traceState().Get(traceStateKey))
+
+	s.hasPriority = false
+	assert.Equal(t, 0, s.traceState().Len())
+}
+
+func TestParseStateIgnoresMalformedFields(t *testing.T) {
+	got := parseState("s:x;o;t.:v;p:00f067aa0ba902b7;t.dm:-4;unknown:1")
+	assert.Equal(t, state{tags: []tag{{key: "_dd.p.dm", value: "-4"}}}, got)
+}
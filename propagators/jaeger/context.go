@@ -14,12 +14,24 @@
 
 package jaeger // import "go.opentelemetry.io/contrib/propagators/jaeger"
 
-import "context"
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// DebugIDKey is the attribute key used by Jaeger clients to record the value
+// of the jaeger-debug-id header. The jaegerdebug sampler of
+// go.opentelemetry.io/contrib/samplers/jaegerdebug sets it on the first span
+// started from an extracted context, which lets the trace be found by its
+// debug id.
+const DebugIDKey = attribute.Key("jaeger-debug-id")
 
 type jaegerKeyType int
 
 const (
 	debugKey jaegerKeyType = iota
+	debugIDKey
 )
 
 // withDebug returns a copy of parent with debug set as the debug flag value .
@@ -39,3 +51,23 @@ func debugFromContext(ctx context.Context) bool {
 	}
 	return false
 }
+
+// withDebugID returns a copy of parent with id set as the jaeger-debug-id
+// value.
+func withDebugID(parent context.Context, id string) context.Context {
+	return context.WithValue(parent, debugIDKey, id)
+}
+
+// DebugIDFromContext returns the jaeger-debug-id value extracted into ctx by
+// the Jaeger propagator.
+//
+// If no debug id is stored in ctx an empty string is returned.
+func DebugIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	if id, ok := ctx.Value(debugIDKey).(string); ok {
+		return id
+	}
+	return ""
+}
@@ -16,14 +16,17 @@ package jaeger_test
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/contrib/propagators/jaeger"
+	"go.opentelemetry.io/otel/baggage"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
 )
@@ -107,3 +110,89 @@ func TestInjectJaeger(t *testing.T) {
 		}
 	}
 }
+
+func TestInjectJaegerBaggage(t *testing.T) {
+	member, err := baggage.NewMember("user", "alice%20smith%2Cadmin")
+	require.NoError(t, err)
+	bags, err := baggage.New(member)
+	require.NoError(t, err)
+
+	ctx := baggage.ContextWithBaggage(context.Background(), bags)
+	ctx = trace.ContextWithSpanContext(ctx, trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: traceID32,
+		SpanID:  spanID,
+	}))
+
+	header := http.Header{}
+	jaeger.Jaeger{}.Inject(ctx, propagation.HeaderCarrier(header))
+	assert.Equal(t, "alice+smith%2Cadmin", header.Get("uberctx-user"))
+}
+
+func TestExtractJaegerBaggage(t *testing.T) {
+	header := http.Header{}
+	header.Set(jaegerHeader, fmt.Sprintf("%s:%s:0:1", traceID32Str, spanIDStr))
+	header.Set("Uberctx-User", "alice+smith%2Cadmin")
+	header.Set("Uberctx-Invalid", "%zz")
+	header.Set("Uberctx-Tenant", "a/b")
+
+	ctx := jaeger.Jaeger{}.Extract(context.Background(), propagation.HeaderCarrier(header))
+	bags := baggage.FromContext(ctx)
+	assert.Equal(t, 2, bags.Len())
+	assert.Equal(t, "alice smith,admin", bags.Member("user").Value())
+	assert.Equal(t, "a/b", bags.Member("tenant").Value())
+	assert.True(t, trace.SpanContextFromContext(ctx).IsValid())
+}
+
+func TestJaegerBaggageRoundTrip(t *testing.T) {
+	m1, err := baggage.NewMember("key1", "val%201")
+	require.NoError(t, err)
+	m2, err := baggage.NewMember("key2", "a%3Db%3Bc+d")
+	require.NoError(t, err)
+	want, err := baggage.New(m1, m2)
+	require.NoError(t, err)
+
+	ctx := baggage.ContextWithBaggage(context.Background(), want)
+	ctx = trace.ContextWithSpanContext(ctx, trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: traceID32,
+		SpanID:  spanID,
+	}))
+
+	header := http.Header{}
+	propagator := jaeger.Jaeger{}
+	propagator.Inject(ctx, propagation.HeaderCarrier(header))
+	got := baggage.FromContext(propagator.Extract(context.Background(), propagation.HeaderCarrier(header)))
+
+	assert.Equal(t, want.Len(), got.Len())
+	for _, m := range want.Members() {
+		assert.Equal(t, m.Value(), got.Member(m.Key()).Value(), m.Key())
+	}
+}
+
+func TestExtractJaegerDebugID(t *testing.T) {
+	propagator := jaeger.Jaeger{}
+
+	header := http.Header{}
+	header.Set(jaegerHeader, fmt.Sprintf("%s:%s:0:0", traceID32Str, spanIDStr))
+	header.Set("Jaeger-Debug-Id", "my-request")
+
+	ctx := propagator.Extract(context.Background(), propagation.HeaderCarrier(header))
+	sc := trace.SpanContextFromContext(ctx)
+	assert.True(t, sc.IsValid())
+	assert.True(t, sc.IsSampled(), "debug id should force sampling")
+	assert.True(t, jaeger.DebugFromContext(ctx))
+	assert.Equal(t, "my-request", jaeger.DebugIDFromContext(ctx))
+
+	out := http.Header{}
+	propagator.Inject(ctx, propagation.HeaderCarrier(out))
+	assert.Equal(t, fmt.Sprintf("%s:%s:0:3", traceID32Str, spanIDStr), out.Get(jaegerHeader))
+
+	// Without trace context the debug request is still recorded.
+	header = http.Header{}
+	header.Set("Jaeger-Debug-Id", "my-request")
+	ctx = propagator.Extract(context.Background(), propagation.HeaderCarrier(header))
+	assert.False(t, trace.SpanContextFromContext(ctx).IsValid())
+	assert.True(t, jaeger.DebugFromContext(ctx))
+	assert.Equal(t, "my-request", jaeger.DebugIDFromContext(ctx))
+
+	assert.Equal(t, "", jaeger.DebugIDFromContext(context.Background()))
+}
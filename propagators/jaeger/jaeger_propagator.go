@@ -18,15 +18,19 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/url"
 	"strconv"
 	"strings"
 
+	"go.opentelemetry.io/otel/baggage"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
 )
 
 const (
 	jaegerHeader        = "uber-trace-id"
+	debugIDHeader       = "jaeger-debug-id"
+	baggageHeaderPrefix = "uberctx-"
 	separator           = ":"
 	traceID128bitsWidth = 128 / 4
 	spanIDWidth         = 64 / 4
@@ -56,6 +60,13 @@ var (
 // Jaeger format:
 //
 // uber-trace-id: {trace-id}:{span-id}:{parent-span-id}:{flags}.
+//
+// Baggage members are propagated as uberctx-{key}: {url-encoded-value}
+// headers. A jaeger-debug-id header forces the extracted trace to be
+// sampled with the debug flag set, and its value is made available with
+// DebugIDFromContext. When no trace is extracted with it, spans started from
+// the extracted context are only sampled with the jaegerdebug sampler of
+// go.opentelemetry.io/contrib/samplers/jaegerdebug.
 type Jaeger struct{}
 
 var _ propagation.TextMapPropagator = &Jaeger{}
@@ -78,14 +89,34 @@ func (jaeger Jaeger) Inject(ctx context.Context, carrier propagation.TextMapCarr
 	}
 
 	carrier.Set(jaegerHeader, strings.Join(headers, separator))
+
+	for _, m := range baggage.FromContext(ctx).Members() {
+		carrier.Set(baggageHeaderPrefix+m.Key(), url.QueryEscape(m.Value()))
+	}
 }
 
 // Extract extracts a context from the carrier if it contains Jaeger headers.
 func (jaeger Jaeger) Extract(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	if bags := extractBags(carrier); bags.Len() > 0 {
+		ctx = baggage.ContextWithBaggage(ctx, bags)
+	}
+
+	debugID := carrier.Get(debugIDHeader)
+	if debugID != "" {
+		// A debug id requests the trace to be sampled even when the caller
+		// did not start one. The debug flag is kept in ctx for Inject to
+		// propagate, and the debug id for the jaegerdebug sampler to sample
+		// the spans started from ctx.
+		ctx = withDebug(withDebugID(ctx, debugID), true)
+	}
+
 	// extract tracing information
 	if h := carrier.Get(jaegerHeader); h != "" {
 		ctx, sc, err := extract(ctx, h)
 		if err == nil && sc.IsValid() {
+			if debugID != "" {
+				sc = sc.WithTraceFlags(sc.TraceFlags() | trace.FlagsSampled)
+			}
 			return trace.ContextWithRemoteSpanContext(ctx, sc)
 		}
 	}
@@ -93,6 +124,31 @@ func (jaeger Jaeger) Extract(ctx context.Context, carrier propagation.TextMapCar
 	return ctx
 }
 
+// extractBags extracts Jaeger baggage information from carrier. Members
+// with invalid keys or values are dropped.
+func extractBags(carrier propagation.TextMapCarrier) baggage.Baggage {
+	var members []baggage.Member
+	for _, key := range carrier.Keys() {
+		lowerKey := strings.ToLower(key)
+		if !strings.HasPrefix(lowerKey, baggageHeaderPrefix) {
+			continue
+		}
+		value, err := url.QueryUnescape(carrier.Get(key))
+		if err != nil {
+			continue
+		}
+		strippedKey := strings.TrimPrefix(lowerKey, baggageHeaderPrefix)
+		// NewMember expects a percent-encoded value.
+		member, err := baggage.NewMember(strippedKey, url.PathEscape(value))
+		if err != nil {
+			continue
+		}
+		members = append(members, member)
+	}
+	bags, _ := baggage.New(members...)
+	return bags
+}
+
 func extract(ctx context.Context, headerVal string) (context.Context, trace.SpanContext, error) {
 	var (
 		scc = trace.SpanContextConfig{}
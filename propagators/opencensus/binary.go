@@ -16,6 +16,7 @@ package opencensus // import "go.opentelemetry.io/contrib/propagators/opencensus
 
 import (
 	"context"
+	"encoding/base64"
 
 	ocpropagation "go.opencensus.io/trace/propagation"
 
@@ -32,9 +33,25 @@ const binaryKey key = 0
 // https://github.com/census-instrumentation/opencensus-go/blob/3fb168f674736c026e623310bfccb0691e6dec8a/plugin/ocgrpc/trace_common.go#L30
 const binaryHeader = "grpc-trace-bin"
 
+// BinaryCarrier is a TextMapCarrier that can transport binary values as is,
+// like gRPC metadata for keys with the -bin suffix. The carrier used by
+// otelgrpc implements it.
+type BinaryCarrier interface {
+	propagation.TextMapCarrier
+
+	// GetBinary returns the binary value associated with the passed key.
+	GetBinary(key string) []byte
+	// SetBinary stores the binary key-value pair.
+	SetBinary(key string, value []byte)
+}
+
 // Binary is an OpenTelemetry implementation of the OpenCensus grpc binary format.
 // Binary propagation was temporarily removed from opentelemetry.  See
 // https://github.com/open-telemetry/opentelemetry-specification/issues/437
+//
+// The binary header is set as is in a BinaryCarrier. Other carriers, like
+// HTTP headers, only transport text so the header is base64 encoded, as gRPC
+// does on the wire.
 type Binary struct{}
 
 var _ propagation.TextMapPropagator = Binary{}
@@ -42,45 +59,67 @@ var _ propagation.TextMapPropagator = Binary{}
 // Inject injects context into the TextMapCarrier.
 func (b Binary) Inject(ctx context.Context, carrier propagation.TextMapCarrier) {
 	binaryContext := ctx.Value(binaryKey)
-	if state, ok := binaryContext.(string); binaryContext != nil && ok {
-		carrier.Set(binaryHeader, state)
+	if state, ok := binaryContext.([]byte); binaryContext != nil && ok {
+		set(carrier, state)
 	}
 
 	sc := trace.SpanContextFromContext(ctx)
 	if !sc.IsValid() {
 		return
 	}
-	h := ocpropagation.Binary(opencensus.OTelSpanContextToOC(sc))
-	carrier.Set(binaryHeader, string(h))
+	set(carrier, ocpropagation.Binary(opencensus.OTelSpanContextToOC(sc)))
 }
 
 // Extract extracts the SpanContext from the TextMapCarrier.
 func (b Binary) Extract(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
-	state := carrier.Get(binaryHeader)
-	if state != "" {
-		ctx = context.WithValue(ctx, binaryKey, state)
+	state := get(carrier)
+	if len(state) == 0 {
+		return ctx
 	}
+	ctx = context.WithValue(ctx, binaryKey, state)
 
-	sc := b.extract(carrier)
+	ocContext, ok := ocpropagation.FromBinary(state)
+	if !ok {
+		return ctx
+	}
+	sc := opencensus.OCSpanContextToOTel(ocContext)
 	if !sc.IsValid() {
 		return ctx
 	}
 	return trace.ContextWithRemoteSpanContext(ctx, sc)
 }
 
-func (b Binary) extract(carrier propagation.TextMapCarrier) trace.SpanContext {
+// Fields returns the fields that this propagator modifies.
+func (b Binary) Fields() []string {
+	return []string{binaryHeader}
+}
+
+// set sets the binary header in carrier, base64 encoded unless carrier is a
+// BinaryCarrier.
+func set(carrier propagation.TextMapCarrier, h []byte) {
+	if bc, ok := carrier.(BinaryCarrier); ok {
+		bc.SetBinary(binaryHeader, h)
+		return
+	}
+	carrier.Set(binaryHeader, base64.StdEncoding.EncodeToString(h))
+}
+
+// get returns the binary header of carrier. Headers of text carriers are
+// base64 decoded, padded or not. Headers that are not valid base64, as set
+// by earlier versions of Binary, are returned as is.
+func get(carrier propagation.TextMapCarrier) []byte {
+	if bc, ok := carrier.(BinaryCarrier); ok {
+		return bc.GetBinary(binaryHeader)
+	}
 	h := carrier.Get(binaryHeader)
 	if h == "" {
-		return trace.SpanContext{}
+		return nil
 	}
-	ocContext, ok := ocpropagation.FromBinary([]byte(h))
-	if !ok {
-		return trace.SpanContext{}
+	if b, err := base64.StdEncoding.DecodeString(h); err == nil {
+		return b
 	}
-	return opencensus.OCSpanContextToOTel(ocContext)
-}
-
-// Fields returns the fields that this propagator modifies.
-func (b Binary) Fields() []string {
-	return []string{binaryHeader}
+	if b, err := base64.RawStdEncoding.DecodeString(h); err == nil {
+		return b
+	}
+	return []byte(h)
 }
@@ -16,6 +16,7 @@ package opencensus
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
 	"net/http"
 	"testing"
@@ -63,7 +64,7 @@ func TestInject(t *testing.T) {
 				SpanID:     spanID,
 				TraceFlags: trace.FlagsSampled,
 			},
-			wantHeader: fmt.Sprintf(headerFmt, "\x01", "\x01"),
+			wantHeader: base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf(headerFmt, "\x01", "\x01"))),
 		},
 		{
 			desc: "valid spancontext, not sampled",
@@ -71,7 +72,7 @@ func TestInject(t *testing.T) {
 				TraceID: traceID,
 				SpanID:  spanID,
 			},
-			wantHeader: fmt.Sprintf(headerFmt, "\x01", "\x00"),
+			wantHeader: base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf(headerFmt, "\x01", "\x00"))),
 		},
 		{
 			desc: "valid spancontext, with unsupported bit set in traceflags",
@@ -80,7 +81,7 @@ func TestInject(t *testing.T) {
 				SpanID:     spanID,
 				TraceFlags: 0xff,
 			},
-			wantHeader: fmt.Sprintf(headerFmt, "\x01", "\x01"),
+			wantHeader: base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf(headerFmt, "\x01", "\x01"))),
 		},
 		{
 			desc:       "invalid spancontext",
@@ -138,6 +139,23 @@ func TestExtract(t *testing.T) {
 				TraceFlags: trace.FlagsSampled,
 			},
 		},
+		{
+			desc:   "base64 header",
+			header: base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf(headerFmt, "\x02", "\x01"))),
+			wantScc: trace.SpanContextConfig{
+				TraceID:    traceID,
+				SpanID:     childSpanID,
+				TraceFlags: trace.FlagsSampled,
+			},
+		},
+		{
+			desc:   "unpadded base64 header",
+			header: base64.RawStdEncoding.EncodeToString([]byte(fmt.Sprintf(headerFmt, "\x02", "\x00"))),
+			wantScc: trace.SpanContextConfig{
+				TraceID: traceID,
+				SpanID:  childSpanID,
+			},
+		},
 	} {
 		t.Run(tt.desc, func(t *testing.T) {
 			header := http.Header{
@@ -159,3 +177,33 @@ func TestExtract(t *testing.T) {
 		})
 	}
 }
+
+type binaryCarrier map[string][]byte
+
+func (c binaryCarrier) Get(string) string           { panic("unexpected Get") }
+func (c binaryCarrier) Set(string, string)          { panic("unexpected Set") }
+func (c binaryCarrier) Keys() []string              { return nil }
+func (c binaryCarrier) GetBinary(key string) []byte { return c[key] }
+func (c binaryCarrier) SetBinary(key string, value []byte) {
+	c[key] = value
+}
+
+func TestBinaryCarrier(t *testing.T) {
+	prop := Binary{}
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	})
+
+	carrier := binaryCarrier{}
+	prop.Inject(trace.ContextWithRemoteSpanContext(context.Background(), sc), carrier)
+	if got, want := string(carrier["grpc-trace-bin"]), fmt.Sprintf(headerFmt, "\x01", "\x01"); got != want {
+		t.Errorf("Got header = %q, want %q", got, want)
+	}
+
+	gotSc := trace.SpanContextFromContext(prop.Extract(context.Background(), carrier))
+	if !gotSc.Equal(sc.WithRemote(true)) {
+		t.Errorf("Got span context = %v, want %v", gotSc, sc)
+	}
+}
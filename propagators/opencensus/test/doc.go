@@ -0,0 +1,23 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package test validates the OpenCensus binary propagator over gRPC using
+otelgrpc and ocgrpc.
+
+This package is in a separate module from the propagator it tests to isolate
+the gRPC dependencies and not impose them as transitive dependencies for
+users.
+*/
+package test // import "go.opentelemetry.io/contrib/propagators/opencensus/test"
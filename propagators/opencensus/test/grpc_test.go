@@ -0,0 +1,123 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"go.opencensus.io/plugin/ocgrpc"
+	octrace "go.opencensus.io/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/stats"
+	"google.golang.org/grpc/test/bufconn"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/contrib/propagators/opencensus"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+var (
+	grpcTraceID = trace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36}
+	grpcSpanID  = trace.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7}
+)
+
+// healthServer records the context of the last Check call.
+type healthServer struct {
+	healthpb.UnimplementedHealthServer
+
+	ctx context.Context
+}
+
+func (s *healthServer) Check(ctx context.Context, _ *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	s.ctx = ctx
+	return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING}, nil
+}
+
+// check calls the Check method of a health server using serverHandler over
+// a client using clientHandler, and returns the context of the call on the
+// server.
+func check(ctx context.Context, t *testing.T, clientHandler, serverHandler stats.Handler) context.Context {
+	lis := bufconn.Listen(1024 * 1024)
+	srv := &healthServer{}
+	s := grpc.NewServer(grpc.StatsHandler(serverHandler))
+	healthpb.RegisterHealthServer(s, srv)
+	go func() { _ = s.Serve(lis) }()
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(clientHandler),
+	)
+	if err != nil {
+		t.Fatalf("failed to dial: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	if _, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{}); err != nil {
+		t.Fatalf("failed to call Check: %v", err)
+	}
+	return srv.ctx
+}
+
+func TestOTelClientOCServer(t *testing.T) {
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    grpcTraceID,
+		SpanID:     grpcSpanID,
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), sc)
+
+	client := otelgrpc.NewClientHandler(
+		otelgrpc.WithTracerProvider(noop.NewTracerProvider()),
+		otelgrpc.WithPropagators(opencensus.Binary{}),
+	)
+	server := &ocgrpc.ServerHandler{
+		StartOptions: octrace.StartOptions{Sampler: octrace.AlwaysSample()},
+	}
+
+	got := octrace.FromContext(check(ctx, t, client, server)).SpanContext()
+	if got.TraceID != octrace.TraceID(grpcTraceID) {
+		t.Errorf("Got trace ID %v, want %v", got.TraceID, grpcTraceID)
+	}
+	if !got.IsSampled() {
+		t.Error("Got span context not sampled, want sampled")
+	}
+}
+
+func TestOCClientOTelServer(t *testing.T) {
+	ctx, span := octrace.StartSpan(context.Background(), "client", octrace.WithSampler(octrace.AlwaysSample()))
+	defer span.End()
+	want := span.SpanContext()
+
+	client := &ocgrpc.ClientHandler{}
+	server := otelgrpc.NewServerHandler(
+		otelgrpc.WithTracerProvider(noop.NewTracerProvider()),
+		otelgrpc.WithPropagators(opencensus.Binary{}),
+	)
+
+	got := trace.SpanContextFromContext(check(ctx, t, client, server))
+	if got.TraceID() != trace.TraceID(want.TraceID) {
+		t.Errorf("Got trace ID %v, want %v", got.TraceID(), want.TraceID)
+	}
+	if !got.IsSampled() {
+		t.Error("Got span context not sampled, want sampled")
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package jaegerdebug provides a sampler sampling the traces requested with
+// the jaeger-debug-id header extracted by the Jaeger propagator of
+// go.opentelemetry.io/contrib/propagators/jaeger.
+package jaegerdebug // import "go.opentelemetry.io/contrib/samplers/jaegerdebug"
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/contrib/propagators/jaeger"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Sampler returns a Sampler that samples the spans started from a context
+// into which the Jaeger propagator extracted a jaeger-debug-id header, and
+// delegates the sampling decision of the other spans to base.
+//
+// The first span started from the extracted context, a root span or the
+// child of the extracted remote span context, has the jaeger.DebugIDKey
+// attribute set to the debug id so the trace can be found by it, as with
+// Jaeger clients.
+func Sampler(base sdktrace.Sampler) sdktrace.Sampler {
+	return debugSampler{base: base}
+}
+
+type debugSampler struct {
+	base sdktrace.Sampler
+}
+
+var _ sdktrace.Sampler = debugSampler{}
+
+// ShouldSample samples the spans of a debug trace, delegating to the base
+// Sampler otherwise.
+func (s debugSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	id := jaeger.DebugIDFromContext(p.ParentContext)
+	if id == "" {
+		return s.base.ShouldSample(p)
+	}
+
+	psc := trace.SpanContextFromContext(p.ParentContext)
+	result := sdktrace.SamplingResult{
+		Decision:   sdktrace.RecordAndSample,
+		Tracestate: psc.TraceState(),
+	}
+	if !psc.IsValid() || psc.IsRemote() {
+		result.Attributes = append(result.Attributes, jaeger.DebugIDKey.String(id))
+	}
+	return result
+}
+
+// Description returns the description of the Sampler.
+func (s debugSampler) Description() string {
+	return fmt.Sprintf("JaegerDebugSampler{%s}", s.base.Description())
+}
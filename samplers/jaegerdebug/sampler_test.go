@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaegerdebug_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/contrib/propagators/jaeger"
+	"go.opentelemetry.io/contrib/samplers/jaegerdebug"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func newDebugTracerProvider(t *testing.T) (*sdktrace.TracerProvider, *tracetest.SpanRecorder) {
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSampler(jaegerdebug.Sampler(sdktrace.NeverSample())),
+		sdktrace.WithSpanProcessor(sr),
+	)
+	t.Cleanup(func() { _ = tp.Shutdown(context.Background()) })
+	return tp, sr
+}
+
+func TestDebugSamplerRootSpan(t *testing.T) {
+	tp, sr := newDebugTracerProvider(t)
+	tracer := tp.Tracer("test")
+	prop := jaeger.Jaeger{}
+
+	ctx := prop.Extract(context.Background(), propagation.MapCarrier{"jaeger-debug-id": "my-debug-id"})
+	ctx, root := tracer.Start(ctx, "root")
+	ctx, child := tracer.Start(ctx, "child")
+	child.End()
+	root.End()
+
+	assert.True(t, root.SpanContext().IsSampled())
+	assert.True(t, child.SpanContext().IsSampled())
+	assert.Equal(t, root.SpanContext().TraceID(), child.SpanContext().TraceID())
+
+	spans := sr.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "child", spans[0].Name())
+	assert.NotContains(t, spans[0].Attributes(), jaeger.DebugIDKey.String("my-debug-id"))
+	assert.Equal(t, "root", spans[1].Name())
+	assert.Contains(t, spans[1].Attributes(), jaeger.DebugIDKey.String("my-debug-id"))
+
+	// The debug flag is propagated downstream.
+	carrier := propagation.MapCarrier{}
+	prop.Inject(ctx, carrier)
+	assert.True(t, strings.HasSuffix(carrier.Get("uber-trace-id"), ":3"), carrier.Get("uber-trace-id"))
+}
+
+func TestDebugSamplerRemoteParent(t *testing.T) {
+	tp, sr := newDebugTracerProvider(t)
+
+	ctx := jaeger.Jaeger{}.Extract(context.Background(), propagation.MapCarrier{
+		"uber-trace-id":   "000000000000007b00000000000001c8:000000000000007b:0:0",
+		"jaeger-debug-id": "my-debug-id",
+	})
+	_, span := tp.Tracer("test").Start(ctx, "server")
+	span.End()
+
+	assert.True(t, span.SpanContext().IsSampled())
+	assert.Equal(t, "000000000000007b00000000000001c8", span.SpanContext().TraceID().String())
+	spans := sr.Ended()
+	require.Len(t, spans, 1)
+	assert.Contains(t, spans[0].Attributes(), jaeger.DebugIDKey.String("my-debug-id"))
+}
+
+func TestDebugSamplerDelegates(t *testing.T) {
+	tp, sr := newDebugTracerProvider(t)
+
+	ctx := jaeger.Jaeger{}.Extract(context.Background(), propagation.MapCarrier{})
+	_, span := tp.Tracer("test").Start(ctx, "root")
+	span.End()
+
+	assert.False(t, span.SpanContext().IsSampled())
+	assert.Empty(t, sr.Ended())
+}
+
+func TestDebugSamplerDescription(t *testing.T) {
+	assert.Equal(t, "JaegerDebugSampler{AlwaysOffSampler}", jaegerdebug.Sampler(sdktrace.NeverSample()).Description())
+}